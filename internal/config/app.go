@@ -5,6 +5,7 @@ import (
 	"kasir-api/internal/delivery/http/routes"
 	"kasir-api/internal/repository/postgres"
 	"kasir-api/internal/service"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
@@ -20,30 +21,132 @@ type BootstrapConfig struct {
 }
 
 func Bootstrap(cfg *BootstrapConfig) {
+	txManager := postgres.NewTxManager(cfg.DB)
+
 	categoryRepository := postgres.NewCategoryRepository(cfg.DB)
 	categoryService := service.NewCategoryService(categoryRepository)
 	categoryController := http.NewCategoryController(categoryService)
 
 	productRepository := postgres.NewProductRepository(cfg.DB)
-	productService := service.NewProductService(productRepository, categoryRepository)
+	productService := service.NewProductService(txManager, productRepository, categoryRepository)
 	productController := http.NewProductController(productService)
 
+	taxRateRepository := postgres.NewTaxRateRepository(cfg.DB)
+	taxRateService := service.NewTaxRateService(taxRateRepository, categoryRepository, productRepository)
+	taxRateController := http.NewTaxRateController(taxRateService)
+
+	shiftRepository := postgres.NewShiftRepository(cfg.DB)
+	cashMovementRepository := postgres.NewCashMovementRepository(cfg.DB)
+	shiftService := service.NewShiftService(txManager, shiftRepository, cashMovementRepository)
+	shiftController := http.NewShiftController(shiftService)
+
 	trxRepository := postgres.NewTrxRepository(cfg.DB)
 	trxDetRepository := postgres.NewTrxDetailRepository(cfg.DB)
-	trxService := service.NewTrxService(productRepository, trxRepository, trxDetRepository)
+	idempotencyRepository := postgres.NewIdempotencyRepository(cfg.DB)
+	trxPaymentRepository := postgres.NewTrxPaymentRepository(cfg.DB)
+	invoiceCounterRepository := postgres.NewInvoiceCounterRepository(cfg.DB)
+	customerRepository := postgres.NewCustomerRepository(cfg.DB)
+	loyaltyRepository := postgres.NewLoyaltyRepository(cfg.DB)
+	creditRepository := postgres.NewCreditRepository(cfg.DB)
+	customerGroupRepository := postgres.NewCustomerGroupRepository(cfg.DB)
+	promotionRepository := postgres.NewPromotionRepository(cfg.DB)
+	voucherRepository := postgres.NewVoucherRepository(cfg.DB)
+	checkoutConfig := service.CheckoutConfig{
+		Rounding:          newRoundingPolicy(cfg.Config, cfg.Logger),
+		ServiceChargeRate: cfg.Config.GetFloat64("checkout.service_charge.rate"),
+		Invoice:           newInvoiceConfig(cfg.Config, cfg.Logger),
+		Loyalty: service.LoyaltyConfig{
+			AmountPerPoint: cfg.Config.GetInt("loyalty.earn.amount_per_point"),
+			PointValue:     cfg.Config.GetInt("loyalty.redeem.point_value"),
+		},
+	}
+	trxService := service.NewTrxService(txManager, productRepository, trxRepository, trxDetRepository, trxPaymentRepository, taxRateRepository, idempotencyRepository, invoiceCounterRepository, shiftRepository, customerRepository, customerGroupRepository, loyaltyRepository, creditRepository, promotionRepository, voucherRepository, checkoutConfig)
 	trxController := http.NewTrxController(trxService)
 
+	customerService := service.NewCustomerService(customerRepository, customerGroupRepository, loyaltyRepository, trxService)
+	customerController := http.NewCustomerController(customerService)
+
+	customerGroupService := service.NewCustomerGroupService(customerGroupRepository, categoryRepository, productRepository)
+	customerGroupController := http.NewCustomerGroupController(customerGroupService)
+
+	promotionService := service.NewPromotionService(promotionRepository, categoryRepository, productRepository)
+	promotionController := http.NewPromotionController(promotionService)
+
+	voucherService := service.NewVoucherService(txManager, voucherRepository, categoryRepository)
+	voucherController := http.NewVoucherController(voucherService)
+
+	creditService := service.NewCreditService(txManager, customerRepository, creditRepository, shiftRepository)
+	creditController := http.NewCreditController(creditService)
+
+	storeSettingRepository := postgres.NewStoreSettingRepository(cfg.DB)
+	storeSettingService := service.NewStoreSettingService(storeSettingRepository)
+	storeSettingController := http.NewStoreSettingController(storeSettingService)
+
+	receiptService := service.NewReceiptService(trxService, storeSettingRepository, checkoutConfig.Invoice.Location)
+	receiptController := http.NewReceiptController(receiptService)
+
+	trxReturnRepository := postgres.NewTrxReturnRepository(cfg.DB)
+	returnService := service.NewReturnService(txManager, productRepository, trxRepository, trxDetRepository, trxPaymentRepository, trxReturnRepository, shiftRepository, loyaltyRepository, creditRepository)
+	returnController := http.NewReturnController(returnService)
+
 	reportRepository := postgres.NewReportRepository(cfg.DB)
 	reportService := service.NewReportService(reportRepository)
 	reportController := http.NewReportController(reportService)
 
 	routeConfig := routes.RouteConfig{
-		App:                cfg.App,
-		CategoryController: categoryController,
-		ProductController:  productController,
-		TrxController:      trxController,
-		ReportController:   reportController,
+		App:                     cfg.App,
+		CategoryController:      categoryController,
+		ProductController:       productController,
+		TrxController:           trxController,
+		ReturnController:        returnController,
+		ReportController:        reportController,
+		TaxRateController:       taxRateController,
+		ReceiptController:       receiptController,
+		StoreController:         storeSettingController,
+		ShiftController:         shiftController,
+		CustomerController:      customerController,
+		CreditController:        creditController,
+		CustomerGroupController: customerGroupController,
+		PromotionController:     promotionController,
+		VoucherController:       voucherController,
 	}
 
 	routeConfig.Setup()
 }
+
+func newRoundingPolicy(v *viper.Viper, log *zap.Logger) service.RoundingPolicy {
+	v.SetDefault("checkout.rounding.mode", service.RoundingNone)
+
+	p := service.RoundingPolicy{
+		Mode:      v.GetString("checkout.rounding.mode"),
+		Increment: v.GetInt("checkout.rounding.increment"),
+	}
+	if err := p.Validate(); err != nil {
+		log.Fatal("Invalid rounding config:", zap.Error(err))
+	}
+
+	return p
+}
+
+func newInvoiceConfig(v *viper.Viper, log *zap.Logger) service.InvoiceConfig {
+	v.SetDefault("invoice.format", service.DefaultInvoiceFormat)
+	v.SetDefault("invoice.scope", service.InvoiceScopeStore)
+	v.SetDefault("invoice.timezone", "Asia/Jakarta")
+
+	loc, err := time.LoadLocation(v.GetString("invoice.timezone"))
+	if err != nil {
+		log.Fatal("Invalid invoice timezone:", zap.Error(err))
+	}
+
+	c := service.InvoiceConfig{
+		Format:    v.GetString("invoice.format"),
+		Scope:     v.GetString("invoice.scope"),
+		StoreCode: v.GetString("invoice.store_code"),
+		Location:  loc,
+	}
+	if err := c.Validate(); err != nil {
+		log.Fatal("Invalid invoice config:", zap.Error(err))
+	}
+
+	return c
+}
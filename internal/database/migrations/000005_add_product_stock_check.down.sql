@@ -0,0 +1 @@
+ALTER TABLE product DROP CONSTRAINT IF EXISTS chk_product_stock_non_negative;
\ No newline at end of file
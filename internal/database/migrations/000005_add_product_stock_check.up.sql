@@ -0,0 +1,2 @@
+ALTER TABLE product
+    ADD CONSTRAINT chk_product_stock_non_negative CHECK (stock >= 0);
\ No newline at end of file
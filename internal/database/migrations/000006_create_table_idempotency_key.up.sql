@@ -0,0 +1,7 @@
+CREATE TABLE idempotency_key (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    transaction_id INT REFERENCES transaction(id) ON DELETE CASCADE,
+    response_body JSONB,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transaction_status_created_at;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status_updated_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE transaction
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'completed',
+    ADD COLUMN status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN status_updated_at TIMESTAMPTZ;
+
+CREATE INDEX idx_transaction_status_created_at ON transaction (status, created_at);
\ No newline at end of file
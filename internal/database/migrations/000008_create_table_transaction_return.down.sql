@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS transaction_return_detail;
+DROP TABLE IF EXISTS transaction_return;
+
+ALTER TABLE transaction_detail
+    DROP CONSTRAINT IF EXISTS chk_transaction_detail_returned_quantity,
+    DROP COLUMN IF EXISTS returned_quantity;
\ No newline at end of file
@@ -0,0 +1,24 @@
+ALTER TABLE transaction_detail
+    ADD COLUMN returned_quantity INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_transaction_detail_returned_quantity
+        CHECK (returned_quantity >= 0 AND returned_quantity <= quantity);
+
+CREATE TABLE transaction_return (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL REFERENCES transaction(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    refund_amount INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE transaction_return_detail (
+    id SERIAL PRIMARY KEY,
+    return_id INT NOT NULL REFERENCES transaction_return(id) ON DELETE CASCADE,
+    transaction_detail_id INT NOT NULL REFERENCES transaction_detail(id),
+    product_id INT NOT NULL REFERENCES product(id),
+    quantity INT NOT NULL,
+    refund_amount INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_transaction_return_transaction_id ON transaction_return (transaction_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS unit_price,
+    DROP COLUMN IF EXISTS product_name,
+    DROP COLUMN IF EXISTS category_id,
+    DROP COLUMN IF EXISTS category_name;
\ No newline at end of file
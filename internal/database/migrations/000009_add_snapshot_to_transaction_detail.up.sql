@@ -0,0 +1,15 @@
+ALTER TABLE transaction_detail
+    ADD COLUMN unit_price INT NOT NULL DEFAULT 0,
+    ADD COLUMN product_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN category_id INT,
+    ADD COLUMN category_name TEXT NOT NULL DEFAULT '';
+
+-- backfill data lama dari master product/category saat ini
+UPDATE transaction_detail td
+SET unit_price = CASE WHEN td.quantity > 0 THEN td.subtotal / td.quantity ELSE 0 END,
+    product_name = p.name,
+    category_id = p.category_id,
+    category_name = c.name
+FROM product p
+JOIN category c ON c.id = p.category_id
+WHERE p.id = td.product_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS transaction_payment;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS paid_amount,
+    DROP COLUMN IF EXISTS change_amount;
\ No newline at end of file
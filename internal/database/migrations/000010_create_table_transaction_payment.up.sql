@@ -0,0 +1,14 @@
+ALTER TABLE transaction
+    ADD COLUMN paid_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN change_amount INT NOT NULL DEFAULT 0;
+
+CREATE TABLE transaction_payment (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL REFERENCES transaction(id) ON DELETE CASCADE,
+    method TEXT NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    reference TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_transaction_payment_transaction_id ON transaction_payment (transaction_id);
\ No newline at end of file
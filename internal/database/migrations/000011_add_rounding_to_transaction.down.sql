@@ -0,0 +1,2 @@
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS rounding_amount;
\ No newline at end of file
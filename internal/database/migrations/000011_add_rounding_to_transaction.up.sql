@@ -0,0 +1,2 @@
+ALTER TABLE transaction
+    ADD COLUMN rounding_amount INT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS cart_discount_amount;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS subtotal_amount,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS cart_discount_amount;
\ No newline at end of file
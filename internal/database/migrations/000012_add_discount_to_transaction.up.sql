@@ -0,0 +1,11 @@
+ALTER TABLE transaction
+    ADD COLUMN subtotal_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN discount_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN cart_discount_amount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction_detail
+    ADD COLUMN discount_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN cart_discount_amount INT NOT NULL DEFAULT 0;
+
+-- data lama belum ada diskon: subtotal = total
+UPDATE transaction SET subtotal_amount = total_amount;
\ No newline at end of file
@@ -0,0 +1,14 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS service_charge_amount,
+    DROP COLUMN IF EXISTS tax_name,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax_inclusive,
+    DROP COLUMN IF EXISTS tax_base_amount,
+    DROP COLUMN IF EXISTS tax_amount;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS service_charge_amount,
+    DROP COLUMN IF EXISTS tax_base_amount,
+    DROP COLUMN IF EXISTS tax_amount;
+
+DROP TABLE IF EXISTS tax_rate;
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE TABLE tax_rate (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    rate NUMERIC(5,2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    category_id INT REFERENCES category(id) ON DELETE CASCADE,
+    product_id INT REFERENCES product(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_tax_rate_single_target CHECK (category_id IS NULL OR product_id IS NULL)
+);
+
+-- maksimal satu tarif per product, per category, dan satu tarif default
+CREATE UNIQUE INDEX uq_tax_rate_product ON tax_rate (product_id) WHERE product_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_tax_rate_category ON tax_rate (category_id) WHERE category_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_tax_rate_default ON tax_rate ((TRUE)) WHERE category_id IS NULL AND product_id IS NULL;
+
+ALTER TABLE transaction
+    ADD COLUMN service_charge_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN tax_base_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction_detail
+    ADD COLUMN service_charge_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN tax_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN tax_base_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount INT NOT NULL DEFAULT 0;
+
+-- data lama tanpa pajak: dasar pengenaan = nilai baris
+UPDATE transaction SET tax_base_amount = total_amount;
+UPDATE transaction_detail SET tax_base_amount = subtotal;
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS uq_transaction_invoice_number;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS terminal_id,
+    DROP COLUMN IF EXISTS invoice_number;
+
+DROP TABLE IF EXISTS invoice_counter;
\ No newline at end of file
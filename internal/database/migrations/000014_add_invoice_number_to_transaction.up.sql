@@ -0,0 +1,17 @@
+CREATE TABLE invoice_counter (
+    scope TEXT NOT NULL,
+    period DATE NOT NULL,
+    last_value INT NOT NULL,
+    PRIMARY KEY (scope, period)
+);
+
+ALTER TABLE transaction
+    ADD COLUMN invoice_number TEXT,
+    ADD COLUMN terminal_id TEXT NOT NULL DEFAULT '';
+
+-- transaksi lama belum punya nomor invoice, pakai id supaya tetap unik
+UPDATE transaction SET invoice_number = 'TRX-' || id;
+
+ALTER TABLE transaction ALTER COLUMN invoice_number SET NOT NULL;
+
+CREATE UNIQUE INDEX uq_transaction_invoice_number ON transaction (invoice_number);
\ No newline at end of file
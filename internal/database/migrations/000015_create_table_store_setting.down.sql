@@ -0,0 +1 @@
+DROP TABLE IF EXISTS store_setting;
\ No newline at end of file
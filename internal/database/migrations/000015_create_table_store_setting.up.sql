@@ -0,0 +1,13 @@
+-- satu baris saja (id = 1), identitas toko untuk struk & invoice
+CREATE TABLE store_setting (
+    id INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    name TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    npwp TEXT NOT NULL DEFAULT '',
+    receipt_footer TEXT NOT NULL DEFAULT '',
+    invoice_template TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+INSERT INTO store_setting (id) VALUES (1);
\ No newline at end of file
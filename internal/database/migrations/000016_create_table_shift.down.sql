@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_transaction_return_shift_id;
+
+ALTER TABLE transaction_return
+    DROP COLUMN IF EXISTS refund_method,
+    DROP COLUMN IF EXISTS shift_id;
+
+DROP INDEX IF EXISTS idx_transaction_refund_shift_id;
+DROP INDEX IF EXISTS idx_transaction_shift_id;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS refund_amount,
+    DROP COLUMN IF EXISTS refund_method,
+    DROP COLUMN IF EXISTS refund_shift_id,
+    DROP COLUMN IF EXISTS shift_id;
+
+DROP TABLE IF EXISTS shift;
\ No newline at end of file
@@ -0,0 +1,33 @@
+CREATE TABLE shift (
+    id SERIAL PRIMARY KEY,
+    cashier_name TEXT NOT NULL,
+    terminal_id TEXT NOT NULL,
+    opening_float INT NOT NULL CHECK (opening_float >= 0),
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    expected_cash INT,
+    counted_cash INT,
+    cash_difference INT,
+    closing_note TEXT NOT NULL DEFAULT '',
+    opened_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+-- satu terminal hanya boleh punya satu shift yang masih open
+CREATE UNIQUE INDEX uq_shift_open_terminal ON shift (terminal_id) WHERE status = 'open';
+
+-- shift_id: shift tempat transaksi terjadi
+-- refund_shift_id: shift (laci) yang mengeluarkan uang saat void/refund
+ALTER TABLE transaction
+    ADD COLUMN shift_id INT REFERENCES shift(id),
+    ADD COLUMN refund_shift_id INT REFERENCES shift(id),
+    ADD COLUMN refund_method TEXT NOT NULL DEFAULT '',
+    ADD COLUMN refund_amount INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_transaction_shift_id ON transaction (shift_id);
+CREATE INDEX idx_transaction_refund_shift_id ON transaction (refund_shift_id);
+
+ALTER TABLE transaction_return
+    ADD COLUMN shift_id INT REFERENCES shift(id),
+    ADD COLUMN refund_method TEXT NOT NULL DEFAULT 'cash';
+
+CREATE INDEX idx_transaction_return_shift_id ON transaction_return (shift_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cash_movement;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE cash_movement (
+    id SERIAL PRIMARY KEY,
+    shift_id INT NOT NULL REFERENCES shift(id),
+    direction TEXT NOT NULL CHECK (direction IN ('in', 'out')),
+    amount INT NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    performed_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cash_movement_shift_id ON cash_movement (shift_id);
+CREATE INDEX idx_cash_movement_created_at ON cash_movement (created_at);
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transaction_customer_id;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS customer_id;
+
+DROP TABLE IF EXISTS customer;
\ No newline at end of file
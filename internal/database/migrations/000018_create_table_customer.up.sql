@@ -0,0 +1,19 @@
+CREATE TABLE customer (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    phone TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- nomor HP boleh kosong, tapi kalau diisi harus unik
+CREATE UNIQUE INDEX uq_customer_phone ON customer (phone) WHERE phone <> '';
+CREATE INDEX idx_customer_name ON customer (LOWER(name));
+
+ALTER TABLE transaction
+    ADD COLUMN customer_id INT REFERENCES customer(id);
+
+CREATE INDEX idx_transaction_customer_id ON transaction (customer_id);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS loyalty_ledger;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS points_earned,
+    DROP COLUMN IF EXISTS points_redeemed;
+
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS points_balance;
\ No newline at end of file
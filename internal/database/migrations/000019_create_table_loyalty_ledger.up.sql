@@ -0,0 +1,21 @@
+-- saldo poin boleh minus: earn yang di-reverse setelah poinnya terpakai tetap dicatat sebagai utang poin
+ALTER TABLE customer
+    ADD COLUMN points_balance INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction
+    ADD COLUMN points_earned INT NOT NULL DEFAULT 0,
+    ADD COLUMN points_redeemed INT NOT NULL DEFAULT 0;
+
+CREATE TABLE loyalty_ledger (
+    id SERIAL PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customer(id),
+    transaction_id INT REFERENCES transaction(id),
+    type TEXT NOT NULL CHECK (type IN ('earn', 'redeem', 'reversal')),
+    points INT NOT NULL,
+    balance_after INT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_loyalty_ledger_customer_id ON loyalty_ledger (customer_id, id);
+CREATE INDEX idx_loyalty_ledger_transaction_id ON loyalty_ledger (transaction_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS credit_repayment;
+
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS credit_limit,
+    DROP COLUMN IF EXISTS credit_balance;
\ No newline at end of file
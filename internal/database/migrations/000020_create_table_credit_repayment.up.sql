@@ -0,0 +1,20 @@
+-- credit_balance = piutang customer (penjualan credit - pelunasan). Bisa minus kalau transaksi
+-- credit yang sudah dilunasi kemudian di-void; sisanya jadi titipan untuk belanja credit berikutnya.
+ALTER TABLE customer
+    ADD COLUMN credit_limit INT NOT NULL DEFAULT 0 CHECK (credit_limit >= 0),
+    ADD COLUMN credit_balance INT NOT NULL DEFAULT 0;
+
+CREATE TABLE credit_repayment (
+    id SERIAL PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customer(id),
+    shift_id INT REFERENCES shift(id),
+    terminal_id TEXT NOT NULL DEFAULT '',
+    method TEXT NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    reference TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_repayment_customer_id ON credit_repayment (customer_id, created_at);
+CREATE INDEX idx_credit_repayment_shift_id ON credit_repayment (shift_id);
\ No newline at end of file
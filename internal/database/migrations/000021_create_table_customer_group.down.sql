@@ -0,0 +1,12 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS base_price,
+    DROP COLUMN IF EXISTS price_list_id,
+    DROP COLUMN IF EXISTS price_list_name;
+
+DROP INDEX IF EXISTS idx_customer_customer_group_id;
+
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS customer_group_id;
+
+DROP TABLE IF EXISTS group_price;
+DROP TABLE IF EXISTS customer_group;
\ No newline at end of file
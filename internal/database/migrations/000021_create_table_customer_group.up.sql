@@ -0,0 +1,38 @@
+CREATE TABLE customer_group (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- harga khusus grup: fixed = harga jual pengganti, percent = potongan persen dari harga normal
+CREATE TABLE group_price (
+    id SERIAL PRIMARY KEY,
+    customer_group_id INT NOT NULL REFERENCES customer_group(id) ON DELETE CASCADE,
+    product_id INT REFERENCES product(id) ON DELETE CASCADE,
+    category_id INT REFERENCES category(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('fixed', 'percent')),
+    value NUMERIC(12,2) NOT NULL CHECK (value >= 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_group_price_single_target CHECK ((product_id IS NULL) <> (category_id IS NULL)),
+    CONSTRAINT chk_group_price_percent CHECK (type <> 'percent' OR value <= 100)
+);
+
+-- maksimal satu harga per product dan per category di tiap grup
+CREATE UNIQUE INDEX uq_group_price_product ON group_price (customer_group_id, product_id) WHERE product_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_group_price_category ON group_price (customer_group_id, category_id) WHERE category_id IS NOT NULL;
+
+ALTER TABLE customer
+    ADD COLUMN customer_group_id INT REFERENCES customer_group(id);
+
+CREATE INDEX idx_customer_customer_group_id ON customer (customer_group_id);
+
+-- base_price: harga normal product saat transaksi, unit_price: harga yang benar-benar dipakai
+ALTER TABLE transaction_detail
+    ADD COLUMN base_price INT NOT NULL DEFAULT 0,
+    ADD COLUMN price_list_id INT REFERENCES customer_group(id) ON DELETE SET NULL,
+    ADD COLUMN price_list_name TEXT NOT NULL DEFAULT '';
+
+UPDATE transaction_detail SET base_price = unit_price;
\ No newline at end of file
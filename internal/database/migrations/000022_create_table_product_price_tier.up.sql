@@ -0,0 +1,9 @@
+-- harga grosir: beli >= min_quantity dapat harga per unit price
+CREATE TABLE product_price_tier (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    min_quantity INT NOT NULL CHECK (min_quantity >= 2),
+    price INT NOT NULL CHECK (price > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT uq_product_price_tier UNIQUE (product_id, min_quantity)
+);
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS promotion_amount,
+    DROP COLUMN IF EXISTS promotion_name;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS promotion_amount;
+
+DROP INDEX IF EXISTS idx_promotion_active;
+DROP TABLE IF EXISTS promotion;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- buy_x_get_y: beli buy_quantity gratis get_quantity (unit termurah yang gratis)
+-- bundle_price: buy_quantity unit seharga bundle_price
+-- percent_off: potongan percent dari harga
+CREATE TABLE promotion (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('buy_x_get_y', 'bundle_price', 'percent_off')),
+    product_id INT REFERENCES product(id) ON DELETE CASCADE,
+    category_id INT REFERENCES category(id) ON DELETE CASCADE,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    bundle_price INT NOT NULL DEFAULT 0,
+    percent NUMERIC(5,2) NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    -- jam berlaku harian (happy hour) di timezone toko, format HH:MM
+    start_time TEXT NOT NULL DEFAULT '',
+    end_time TEXT NOT NULL DEFAULT '',
+    priority INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_promotion_single_target CHECK ((product_id IS NULL) <> (category_id IS NULL)),
+    CONSTRAINT chk_promotion_window CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at)
+);
+
+CREATE INDEX idx_promotion_active ON promotion (active, priority DESC);
+
+ALTER TABLE transaction
+    ADD COLUMN promotion_amount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction_detail
+    ADD COLUMN promotion_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN promotion_name TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE transaction_detail
+    DROP COLUMN IF EXISTS voucher_amount;
+
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS voucher_id,
+    DROP COLUMN IF EXISTS voucher_code,
+    DROP COLUMN IF EXISTS voucher_amount;
+
+DROP INDEX IF EXISTS idx_voucher_redemption_customer;
+DROP TABLE IF EXISTS voucher_redemption;
+DROP TABLE IF EXISTS voucher_category;
+DROP TABLE IF EXISTS voucher;
\ No newline at end of file
@@ -0,0 +1,48 @@
+-- code disimpan uppercase; usage_limit & per_customer_limit 0 berarti tanpa batas,
+-- max_discount 0 berarti potongan percent tidak dibatasi
+CREATE TABLE voucher (
+    id SERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL CHECK (type IN ('fixed', 'percent')),
+    value NUMERIC(12,2) NOT NULL CHECK (value > 0),
+    max_discount INT NOT NULL DEFAULT 0 CHECK (max_discount >= 0),
+    min_spend INT NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    expires_at TIMESTAMPTZ,
+    usage_limit INT NOT NULL DEFAULT 0 CHECK (usage_limit >= 0),
+    per_customer_limit INT NOT NULL DEFAULT 0 CHECK (per_customer_limit >= 0),
+    used_count INT NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_voucher_percent CHECK (type <> 'percent' OR value <= 100),
+    CONSTRAINT chk_voucher_usage CHECK (usage_limit = 0 OR used_count <= usage_limit)
+);
+
+-- tanpa baris berarti voucher berlaku untuk semua category
+CREATE TABLE voucher_category (
+    voucher_id INT NOT NULL REFERENCES voucher(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES category(id) ON DELETE CASCADE,
+    PRIMARY KEY (voucher_id, category_id)
+);
+
+CREATE TABLE voucher_redemption (
+    id SERIAL PRIMARY KEY,
+    voucher_id INT NOT NULL REFERENCES voucher(id),
+    transaction_id INT NOT NULL UNIQUE REFERENCES transaction(id),
+    customer_id INT REFERENCES customer(id),
+    amount INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'redeemed' CHECK (status IN ('redeemed', 'released')),
+    released_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_voucher_redemption_customer ON voucher_redemption (voucher_id, customer_id) WHERE status = 'redeemed';
+
+ALTER TABLE transaction
+    ADD COLUMN voucher_id INT REFERENCES voucher(id),
+    ADD COLUMN voucher_code TEXT NOT NULL DEFAULT '',
+    ADD COLUMN voucher_amount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction_detail
+    ADD COLUMN voucher_amount INT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE store_setting
+    DROP COLUMN IF EXISTS invoice_pdf_template;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE store_setting
+    ADD COLUMN invoice_pdf_template TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE transaction_return
+    DROP COLUMN IF EXISTS points_amount;
\ No newline at end of file
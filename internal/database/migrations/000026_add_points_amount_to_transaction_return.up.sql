@@ -0,0 +1,4 @@
+-- points_amount bagian retur yang dulu dibayar pakai poin, dikembalikan sebagai poin (bukan uang).
+-- refund_amount tetap nominal uang yang keluar.
+ALTER TABLE transaction_return
+    ADD COLUMN points_amount INT NOT NULL DEFAULT 0;
\ No newline at end of file
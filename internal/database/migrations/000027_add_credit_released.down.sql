@@ -0,0 +1,5 @@
+ALTER TABLE transaction
+    DROP COLUMN IF EXISTS credit_released;
+
+ALTER TABLE transaction_return
+    DROP COLUMN IF EXISTS credit_amount;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- piutang yang dilepas saat retur (credit_amount) / void-refund (credit_released). Hanya sebatas saldo
+-- piutang customer, bagian kasbon yang sudah dilunasi dikembalikan sebagai uang.
+ALTER TABLE transaction_return
+    ADD COLUMN credit_amount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transaction
+    ADD COLUMN credit_released INT NOT NULL DEFAULT 0;
+
+-- void / refund sebelumnya selalu melepas seluruh bagian kasbon
+UPDATE transaction t
+SET credit_released = p.amount
+FROM (
+    SELECT transaction_id, SUM(amount) AS amount
+    FROM transaction_payment
+    WHERE method = 'credit'
+    GROUP BY transaction_id
+) p
+WHERE p.transaction_id = t.id AND t.status <> 'completed';
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE voucher_category
+    DROP CONSTRAINT voucher_category_category_id_fkey,
+    ADD CONSTRAINT voucher_category_category_id_fkey
+        FOREIGN KEY (category_id) REFERENCES category(id) ON DELETE CASCADE;
\ No newline at end of file
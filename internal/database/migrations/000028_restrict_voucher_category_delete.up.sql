@@ -0,0 +1,6 @@
+-- voucher tanpa baris voucher_category berlaku untuk semua category, jadi category yang masih
+-- membatasi voucher tidak boleh dihapus (cascade akan diam-diam memperluas voucher).
+ALTER TABLE voucher_category
+    DROP CONSTRAINT voucher_category_category_id_fkey,
+    ADD CONSTRAINT voucher_category_category_id_fkey
+        FOREIGN KEY (category_id) REFERENCES category(id) ON DELETE RESTRICT;
\ No newline at end of file
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type CreditController struct {
+	svc service.CreditService
+}
+
+func NewCreditController(svc service.CreditService) *CreditController {
+	return &CreditController{svc: svc}
+}
+
+func (h *CreditController) CreateRepayment(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CreditController.CreateRepayment"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var req dto.CreateCreditRepayment
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateRepayment(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Credit repayment recorded", res)
+}
+
+func (h *CreditController) GetCreditAccount(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CreditController.GetCreditAccount"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var page, limit int
+	if p, err := helper.ParseIntQuery(ctx, "page"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_page"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid page")
+	} else if p != nil {
+		page = *p
+	}
+
+	if l, err := helper.ParseIntQuery(ctx, "limit"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_limit"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid limit")
+	} else if l != nil {
+		limit = *l
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCreditAccount(reqCtx, id, page, limit)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Credit account found", res)
+}
@@ -0,0 +1,259 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type CustomerGroupController struct {
+	svc service.CustomerGroupService
+}
+
+func NewCustomerGroupController(svc service.CustomerGroupService) *CustomerGroupController {
+	return &CustomerGroupController{svc: svc}
+}
+
+func (h *CustomerGroupController) CreateCustomerGroup(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.CreateCustomerGroup"),
+	)
+
+	log.Info("in")
+
+	var req dto.CustomerGroup
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateCustomerGroup(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Customer group created", res)
+}
+
+func (h *CustomerGroupController) GetCustomerGroupByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.GetCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCustomerGroupByID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer group found", res)
+}
+
+func (h *CustomerGroupController) GetAllCustomerGroup(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.GetAllCustomerGroup"),
+	)
+
+	log.Info("in")
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetAllCustomerGroup(reqCtx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return response.Success(ctx, http.StatusOK, "Customer groups list", res)
+}
+
+func (h *CustomerGroupController) UpdateCustomerGroupByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.UpdateCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	var req dto.CustomerGroup
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdateCustomerGroupByID(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer group updated", res)
+}
+
+func (h *CustomerGroupController) DeleteCustomerGroupByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.DeleteCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	if err := h.svc.DeleteCustomerGroupByID(reqCtx, id); err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer group deleted", nil)
+}
+
+func (h *CustomerGroupController) CreateGroupPrice(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.CreateGroupPrice"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	var req dto.GroupPrice
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateGroupPrice(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Group price created", res)
+}
+
+func (h *CustomerGroupController) UpdateGroupPrice(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.UpdateGroupPrice"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	priceID, err := helper.ParseUintParam(ctx, "priceId")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_group_price_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid group price ID")
+	}
+
+	var req dto.GroupPrice
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdateGroupPrice(reqCtx, id, priceID, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Group price updated", res)
+}
+
+func (h *CustomerGroupController) DeleteGroupPrice(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerGroupController.DeleteGroupPrice"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_group_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer group ID")
+	}
+
+	priceID, err := helper.ParseUintParam(ctx, "priceId")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_group_price_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid group price ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	if err := h.svc.DeleteGroupPrice(reqCtx, id, priceID); err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Group price deleted", nil)
+}
@@ -0,0 +1,259 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type CustomerController struct {
+	svc service.CustomerService
+}
+
+func NewCustomerController(svc service.CustomerService) *CustomerController {
+	return &CustomerController{svc: svc}
+}
+
+func (h *CustomerController) CreateCustomer(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.CreateCustomer"),
+	)
+
+	log.Info("in")
+
+	var req dto.Customer
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateCustomer(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Customer created", res)
+}
+
+func (h *CustomerController) GetCustomerByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.GetCustomerByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCustomerByID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer found", res)
+}
+
+func (h *CustomerController) GetAllCustomer(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.GetAllCustomer"),
+	)
+
+	log.Info("in")
+
+	f := dto.CustomerFilter{Q: ctx.Query("q")}
+
+	page, err := helper.ParseIntQuery(ctx, "page")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_page"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid page")
+	}
+	if page != nil {
+		f.Page = *page
+	}
+
+	limit, err := helper.ParseIntQuery(ctx, "limit")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_limit"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid limit")
+	}
+	if limit != nil {
+		f.Limit = *limit
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetAllCustomer(reqCtx, f)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res.Items)))
+
+	return response.Success(ctx, http.StatusOK, "Customers found", res)
+}
+
+func (h *CustomerController) UpdateCustomerByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.UpdateCustomerByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var req dto.Customer
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdateCustomerByID(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer updated", res)
+}
+
+func (h *CustomerController) DeleteCustomerByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.DeleteCustomerByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	if err := h.svc.DeleteCustomerByID(reqCtx, id); err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer deleted", nil)
+}
+
+func (h *CustomerController) GetCustomerTransactions(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.GetCustomerTransactions"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var page, limit int
+	if p, err := helper.ParseIntQuery(ctx, "page"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_page"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid page")
+	} else if p != nil {
+		page = *p
+	}
+
+	if l, err := helper.ParseIntQuery(ctx, "limit"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_limit"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid limit")
+	} else if l != nil {
+		limit = *l
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCustomerTransactions(reqCtx, id, page, limit)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer transactions found", res)
+}
+
+func (h *CustomerController) GetCustomerPoints(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "CustomerController.GetCustomerPoints"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var page, limit int
+	if p, err := helper.ParseIntQuery(ctx, "page"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_page"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid page")
+	} else if p != nil {
+		page = *p
+	}
+
+	if l, err := helper.ParseIntQuery(ctx, "limit"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_limit"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid limit")
+	} else if l != nil {
+		limit = *l
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCustomerPoints(reqCtx, id, page, limit)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Customer points found", res)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PromotionController struct {
+	svc service.PromotionService
+}
+
+func NewPromotionController(svc service.PromotionService) *PromotionController {
+	return &PromotionController{svc: svc}
+}
+
+func (h *PromotionController) CreatePromotion(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "PromotionController.CreatePromotion"),
+	)
+
+	log.Info("in")
+
+	var req dto.Promotion
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreatePromotion(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Promotion created", res)
+}
+
+func (h *PromotionController) GetPromotionByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "PromotionController.GetPromotionByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_promotion_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetPromotionByID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Promotion found", res)
+}
+
+func (h *PromotionController) GetAllPromotion(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "PromotionController.GetAllPromotion"),
+	)
+
+	log.Info("in")
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetAllPromotion(reqCtx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return response.Success(ctx, http.StatusOK, "Promotions list", res)
+}
+
+func (h *PromotionController) UpdatePromotionByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "PromotionController.UpdatePromotionByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_promotion_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	var req dto.Promotion
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdatePromotionByID(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Promotion updated", res)
+}
+
+func (h *PromotionController) DeletePromotionByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "PromotionController.DeletePromotionByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_promotion_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	if err := h.svc.DeletePromotionByID(reqCtx, id); err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Promotion deleted", nil)
+}
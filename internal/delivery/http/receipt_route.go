@@ -0,0 +1,105 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/receipt"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReceiptController struct {
+	svc service.ReceiptService
+}
+
+func NewReceiptController(svc service.ReceiptService) *ReceiptController {
+	return &ReceiptController{svc: svc}
+}
+
+// GetReceipt return struk siap print (format=escpos) atau preview plain text (format=text).
+func (h *ReceiptController) GetReceipt(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "ReceiptController.GetReceipt"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	width, err := helper.ParseIntQuery(ctx, "width")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_width"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input width")
+	}
+	if width == nil {
+		w := receipt.Width58
+		width = &w
+	}
+
+	format := ctx.Query("format", receipt.FormatText)
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetReceipt(reqCtx, id, format, *width)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	if format == receipt.FormatESCPOS {
+		ctx.Set(fiber.HeaderContentType, "application/octet-stream")
+	} else {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	}
+
+	return ctx.Status(http.StatusOK).Send(res)
+}
+
+// GetInvoice return invoice A4 sebagai HTML (default) atau PDF.
+// HTML dirender dari invoice_template, PDF dari invoice_pdf_template (layout teks monospace)
+// di store setting, jadi branding HTML tidak otomatis ikut ke PDF.
+func (h *ReceiptController) GetInvoice(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "ReceiptController.GetInvoice"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	format := ctx.Query("format", receipt.InvoiceFormatHTML)
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetInvoice(reqCtx, id, format)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	if format == receipt.InvoiceFormatPDF {
+		ctx.Set(fiber.HeaderContentType, "application/pdf")
+	} else {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	}
+
+	return ctx.Status(http.StatusOK).Send(res)
+}
@@ -51,3 +51,41 @@ func (h *ReportController) GetReport(ctx *fiber.Ctx) error {
 
 	return response.Success(ctx, http.StatusOK, "Get report successfully", res)
 }
+
+func (h *ReportController) GetCreditAging(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "ReportController.GetCreditAging"),
+	)
+
+	log.Info("in")
+
+	asOf, err := helper.ParseDateQuery(ctx, "asOf")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_as_of"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input as of date")
+	}
+
+	customerID, err := helper.ParseIntQuery(ctx, "customerId")
+	if err != nil || (customerID != nil && *customerID <= 0) {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+
+	var id uint
+	if customerID != nil {
+		id = uint(*customerID)
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetCreditAging(reqCtx, asOf, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Get credit aging successfully", res)
+}
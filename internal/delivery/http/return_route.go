@@ -0,0 +1,81 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReturnController struct {
+	svc service.ReturnService
+}
+
+func NewReturnController(svc service.ReturnService) *ReturnController {
+	return &ReturnController{svc: svc}
+}
+
+func (h *ReturnController) CreateReturn(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "ReturnController.CreateReturn"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	var req dto.CreateReturn
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateReturn(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Return created", res)
+}
+
+func (h *ReturnController) GetReturnsByTransactionID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "ReturnController.GetReturnsByTransactionID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetReturnsByTransactionID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return response.Success(ctx, http.StatusOK, "Returns found", res)
+}
@@ -7,11 +7,21 @@ import (
 )
 
 type RouteConfig struct {
-	App                *fiber.App
-	CategoryController *http.CategoryController
-	ProductController  *http.ProductController
-	TrxController      *http.TrxController
-	ReportController   *http.ReportController
+	App                     *fiber.App
+	CategoryController      *http.CategoryController
+	ProductController       *http.ProductController
+	TrxController           *http.TrxController
+	ReturnController        *http.ReturnController
+	ReportController        *http.ReportController
+	TaxRateController       *http.TaxRateController
+	ReceiptController       *http.ReceiptController
+	StoreController         *http.StoreSettingController
+	ShiftController         *http.ShiftController
+	CustomerController      *http.CustomerController
+	CreditController        *http.CreditController
+	CustomerGroupController *http.CustomerGroupController
+	PromotionController     *http.PromotionController
+	VoucherController       *http.VoucherController
 }
 
 func (c *RouteConfig) Setup() {
@@ -38,9 +48,73 @@ func (c *RouteConfig) SetupRegister() {
 
 	trx := api.Group("/transaction")
 	trx.Post("/checkout", c.TrxController.Checkout)
+	trx.Post("/quote", c.TrxController.Quote)
+	trx.Get("", c.TrxController.GetAllTransaction)
+	trx.Get("/:id", c.TrxController.GetTransactionByID)
+	trx.Post("/:id/void", c.TrxController.VoidTransaction)
+	trx.Post("/:id/refund", c.TrxController.RefundTransaction)
+	trx.Post("/:id/return", c.ReturnController.CreateReturn)
+	trx.Get("/:id/return", c.ReturnController.GetReturnsByTransactionID)
+	trx.Get("/:id/receipt", c.ReceiptController.GetReceipt)
+	trx.Get("/:id/invoice", c.ReceiptController.GetInvoice)
+
+	taxRate := api.Group("/tax-rate")
+	taxRate.Post("", c.TaxRateController.CreateTaxRate)
+	taxRate.Get("/:id", c.TaxRateController.GetTaxRateByID)
+	taxRate.Get("", c.TaxRateController.GetAllTaxRate)
+	taxRate.Put("/:id", c.TaxRateController.UpdateTaxRateByID)
+	taxRate.Delete("/:id", c.TaxRateController.DeleteTaxRateByID)
+
+	customer := api.Group("/customer")
+	customer.Post("", c.CustomerController.CreateCustomer)
+	customer.Get("/:id", c.CustomerController.GetCustomerByID)
+	customer.Get("", c.CustomerController.GetAllCustomer)
+	customer.Put("/:id", c.CustomerController.UpdateCustomerByID)
+	customer.Delete("/:id", c.CustomerController.DeleteCustomerByID)
+	customer.Get("/:id/transactions", c.CustomerController.GetCustomerTransactions)
+	customer.Get("/:id/points", c.CustomerController.GetCustomerPoints)
+	customer.Get("/:id/credit", c.CreditController.GetCreditAccount)
+	customer.Post("/:id/credit/repayment", c.CreditController.CreateRepayment)
+
+	customerGroup := api.Group("/customer-group")
+	customerGroup.Post("", c.CustomerGroupController.CreateCustomerGroup)
+	customerGroup.Get("/:id", c.CustomerGroupController.GetCustomerGroupByID)
+	customerGroup.Get("", c.CustomerGroupController.GetAllCustomerGroup)
+	customerGroup.Put("/:id", c.CustomerGroupController.UpdateCustomerGroupByID)
+	customerGroup.Delete("/:id", c.CustomerGroupController.DeleteCustomerGroupByID)
+	customerGroup.Post("/:id/price", c.CustomerGroupController.CreateGroupPrice)
+	customerGroup.Put("/:id/price/:priceId", c.CustomerGroupController.UpdateGroupPrice)
+	customerGroup.Delete("/:id/price/:priceId", c.CustomerGroupController.DeleteGroupPrice)
+
+	promotion := api.Group("/promotion")
+	promotion.Post("", c.PromotionController.CreatePromotion)
+	promotion.Get("/:id", c.PromotionController.GetPromotionByID)
+	promotion.Get("", c.PromotionController.GetAllPromotion)
+	promotion.Put("/:id", c.PromotionController.UpdatePromotionByID)
+	promotion.Delete("/:id", c.PromotionController.DeletePromotionByID)
+
+	voucher := api.Group("/voucher")
+	voucher.Post("", c.VoucherController.CreateVoucher)
+	voucher.Get("/:id", c.VoucherController.GetVoucherByID)
+	voucher.Get("", c.VoucherController.GetAllVoucher)
+	voucher.Put("/:id", c.VoucherController.UpdateVoucherByID)
+	voucher.Delete("/:id", c.VoucherController.DeleteVoucherByID)
+
+	shift := api.Group("/shift")
+	shift.Post("/open", c.ShiftController.OpenShift)
+	shift.Get("/current", c.ShiftController.GetCurrentShift)
+	shift.Post("/cash-movement", c.ShiftController.CreateCashMovement)
+	shift.Get("/:id", c.ShiftController.GetShiftByID)
+	shift.Post("/:id/close", c.ShiftController.CloseShift)
+	shift.Get("/:id/cash-movement", c.ShiftController.GetCashMovements)
+
+	store := api.Group("/store-setting")
+	store.Get("", c.StoreController.GetStoreSetting)
+	store.Put("", c.StoreController.UpdateStoreSetting)
 
 	report := api.Group("/report")
 	report.Get("", c.ReportController.GetReport)
+	report.Get("/credit-aging", c.ReportController.GetCreditAging)
 
 	api.Get("/health", func(ctx *fiber.Ctx) error {
 		return ctx.JSON(fiber.Map{"status": "Ok"})
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type StoreSettingController struct {
+	svc service.StoreSettingService
+}
+
+func NewStoreSettingController(svc service.StoreSettingService) *StoreSettingController {
+	return &StoreSettingController{svc: svc}
+}
+
+func (h *StoreSettingController) GetStoreSetting(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "StoreSettingController.GetStoreSetting"),
+	)
+
+	log.Info("in")
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetStoreSetting(reqCtx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Get store setting successfully", res)
+}
+
+func (h *StoreSettingController) UpdateStoreSetting(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "StoreSettingController.UpdateStoreSetting"),
+	)
+
+	log.Info("in")
+
+	var req dto.StoreSetting
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdateStoreSetting(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Store setting updated", res)
+}
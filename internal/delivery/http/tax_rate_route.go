@@ -0,0 +1,155 @@
+package http
+
+import (
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/helper"
+	"kasir-api/internal/response"
+	"kasir-api/internal/service"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type TaxRateController struct {
+	svc service.TaxRateService
+}
+
+func NewTaxRateController(svc service.TaxRateService) *TaxRateController {
+	return &TaxRateController{svc: svc}
+}
+
+func (h *TaxRateController) CreateTaxRate(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TaxRateController.CreateTaxRate"),
+	)
+
+	log.Info("in")
+
+	var req dto.TaxRate
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.CreateTaxRate(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusCreated, "Tax rate created", res)
+}
+
+func (h *TaxRateController) GetTaxRateByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TaxRateController.GetTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_tax_rate_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid tax rate ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetTaxRateByID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Tax rate found", res)
+}
+
+func (h *TaxRateController) GetAllTaxRate(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TaxRateController.GetAllTaxRate"),
+	)
+
+	log.Info("in")
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetAllTaxRate(reqCtx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return response.Success(ctx, http.StatusOK, "Tax rates list", res)
+}
+
+func (h *TaxRateController) UpdateTaxRateByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TaxRateController.UpdateTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_tax_rate_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid tax rate ID")
+	}
+
+	var req dto.TaxRate
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.UpdateTaxRateByID(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Tax rate updated", res)
+}
+
+func (h *TaxRateController) DeleteTaxRateByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TaxRateController.DeleteTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_tax_rate_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid tax rate ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	if err := h.svc.DeleteTaxRateByID(reqCtx, id); err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Tax rate deleted", nil)
+}
@@ -7,6 +7,7 @@ import (
 	"kasir-api/internal/response"
 	"kasir-api/internal/service"
 	"net/http"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -36,7 +37,7 @@ func (h *TrxController) Checkout(ctx *fiber.Ctx) error {
 
 	reqCtx := middleware.RequestContext(ctx)
 
-	res, err := h.svc.Checkout(reqCtx, req)
+	res, err := h.svc.Checkout(reqCtx, req, ctx.Get("Idempotency-Key"))
 	if err != nil {
 		log.Error("out", zap.Error(err))
 		return helper.WriteServiceError(ctx, err)
@@ -46,3 +47,215 @@ func (h *TrxController) Checkout(ctx *fiber.Ctx) error {
 
 	return response.Success(ctx, http.StatusCreated, "Checkout successfully", res)
 }
+
+func (h *TrxController) Quote(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TrxController.Quote"),
+	)
+
+	log.Info("in")
+
+	var req dto.Checkout
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.Quote(reqCtx, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Quote calculated", res)
+}
+
+func (h *TrxController) GetTransactionByID(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TrxController.GetTransactionByID"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetTransactionByID(reqCtx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Transaction found", res)
+}
+
+func (h *TrxController) GetAllTransaction(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TrxController.GetAllTransaction"),
+	)
+
+	log.Info("in")
+
+	var f dto.TransactionFilter
+	var err error
+
+	if f.StartDate, err = helper.ParseDateQuery(ctx, "startDate"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_start_date"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input start date")
+	}
+
+	if f.EndDate, err = helper.ParseDateQuery(ctx, "endDate"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_end_date"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input end date")
+	}
+
+	if f.MinAmount, err = helper.ParseIntQuery(ctx, "minAmount"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_min_amount"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input min amount")
+	}
+
+	if f.MaxAmount, err = helper.ParseIntQuery(ctx, "maxAmount"); err != nil {
+		log.Warn("out", zap.String("result", "invalid_max_amount"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid input max amount")
+	}
+
+	productID, err := helper.ParseIntQuery(ctx, "productId")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_product_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid product ID")
+	}
+	if productID != nil {
+		f.ProductID = uint(*productID)
+	}
+
+	shiftID, err := helper.ParseIntQuery(ctx, "shiftId")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_shift_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid shift ID")
+	}
+	if shiftID != nil {
+		f.ShiftID = uint(*shiftID)
+	}
+
+	customerID, err := helper.ParseIntQuery(ctx, "customerId")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_customer_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid customer ID")
+	}
+	if customerID != nil {
+		f.CustomerID = uint(*customerID)
+	}
+
+	page, err := helper.ParseIntQuery(ctx, "page")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_page"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid page")
+	}
+	if page != nil {
+		f.Page = *page
+	}
+
+	limit, err := helper.ParseIntQuery(ctx, "limit")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_limit"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid limit")
+	}
+	if limit != nil {
+		f.Limit = *limit
+	}
+
+	f.Status = ctx.Query("status")
+	f.Invoice = strings.TrimSpace(ctx.Query("invoice"))
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.GetAllTransaction(reqCtx, f)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out", zap.Int("count", len(res.Items)))
+
+	return response.Success(ctx, http.StatusOK, "Transactions found", res)
+}
+
+func (h *TrxController) VoidTransaction(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TrxController.VoidTransaction"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	var req dto.ReverseTransaction
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.VoidTransaction(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Transaction voided", res)
+}
+
+func (h *TrxController) RefundTransaction(ctx *fiber.Ctx) error {
+	log := middleware.LoggerFromFiber(ctx).With(
+		zap.String("layer", "controller"),
+		zap.String("operation", "TrxController.RefundTransaction"),
+	)
+
+	log.Info("in")
+
+	id, err := helper.ParseUintParam(ctx, "id")
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_transaction_id"))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	var req dto.ReverseTransaction
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Warn("out", zap.String("result", "invalid_request_body"), zap.Error(err))
+		return response.Error(ctx, http.StatusBadRequest, "Invalid request body")
+	}
+
+	reqCtx := middleware.RequestContext(ctx)
+
+	res, err := h.svc.RefundTransaction(reqCtx, id, req)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return helper.WriteServiceError(ctx, err)
+	}
+
+	log.Info("out")
+
+	return response.Success(ctx, http.StatusOK, "Transaction refunded", res)
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type CreateCashMovement struct {
+	// TerminalID terminal laci, movement dicatat ke shift yang sedang open di terminal ini.
+	TerminalID  string `json:"terminal_id"`
+	Direction   string `json:"direction"`
+	Amount      int    `json:"amount"`
+	Reason      string `json:"reason"`
+	PerformedBy string `json:"performed_by"`
+}
+
+type CashMovement struct {
+	ID          uint      `json:"id"`
+	ShiftID     uint      `json:"shift_id"`
+	Direction   string    `json:"direction"`
+	Amount      int       `json:"amount"`
+	Reason      string    `json:"reason"`
+	PerformedBy string    `json:"performed_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -1,10 +1,76 @@
 package dto
 
 type Checkout struct {
-	Items []CheckoutItem `json:"items"`
+	TerminalID string `json:"terminal_id,omitempty"`
+	CustomerID *uint  `json:"customer_id,omitempty"`
+	// RedeemPoints jumlah poin customer yang ditukar sebagai pembayaran.
+	RedeemPoints int               `json:"redeem_points,omitempty"`
+	Items        []CheckoutItem    `json:"items"`
+	Discount     *Discount         `json:"discount,omitempty"`
+	VoucherCode  string            `json:"voucher_code,omitempty"`
+	Payments     []CheckoutPayment `json:"payments"`
 }
 
 type CheckoutItem struct {
-	ProductID uint `json:"product_id"`
-	Quantity  int  `json:"quantity"`
+	ProductID uint      `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	Discount  *Discount `json:"discount,omitempty"`
+}
+
+// Discount type "percent" (value 0-100) atau "fixed" (value rupiah).
+type Discount struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+type CheckoutPayment struct {
+	Method    string `json:"method"`
+	Amount    int    `json:"amount"`
+	Reference string `json:"reference,omitempty"`
+}
+
+type Quote struct {
+	Subtotal            int         `json:"subtotal"`
+	DiscountAmount      int         `json:"discount_amount"`
+	CartDiscountAmount  int         `json:"cart_discount_amount"`
+	PromotionAmount     int         `json:"promotion_amount"`
+	VoucherCode         string      `json:"voucher_code,omitempty"`
+	VoucherAmount       int         `json:"voucher_amount"`
+	ServiceChargeAmount int         `json:"service_charge_amount"`
+	TaxBaseAmount       int         `json:"tax_base_amount"`
+	TaxAmount           int         `json:"tax_amount"`
+	Total               int         `json:"total"`
+	CashTotal           int         `json:"cash_total"`
+	RoundingAmount      int         `json:"rounding_amount"`
+	PaidAmount          int         `json:"paid_amount"`
+	ChangeAmount        int         `json:"change_amount"`
+	PointsEarned        int         `json:"points_earned"`
+	InStock             bool        `json:"in_stock"`
+	Lines               []QuoteLine `json:"lines"`
+}
+
+type QuoteLine struct {
+	ProductID           uint    `json:"product_id"`
+	ProductName         string  `json:"product_name"`
+	CategoryID          uint    `json:"category_id"`
+	CategoryName        string  `json:"category_name"`
+	BasePrice           int     `json:"base_price"`
+	UnitPrice           int     `json:"unit_price"`
+	PriceListName       string  `json:"price_list_name,omitempty"`
+	Quantity            int     `json:"quantity"`
+	DiscountAmount      int     `json:"discount_amount"`
+	CartDiscountAmount  int     `json:"cart_discount_amount"`
+	PromotionAmount     int     `json:"promotion_amount"`
+	PromotionName       string  `json:"promotion_name,omitempty"`
+	VoucherAmount       int     `json:"voucher_amount"`
+	Subtotal            int     `json:"subtotal"`
+	ServiceChargeAmount int     `json:"service_charge_amount"`
+	TaxName             string  `json:"tax_name,omitempty"`
+	TaxRate             float64 `json:"tax_rate"`
+	TaxInclusive        bool    `json:"tax_inclusive"`
+	TaxBaseAmount       int     `json:"tax_base_amount"`
+	TaxAmount           int     `json:"tax_amount"`
+	Total               int     `json:"total"`
+	AvailableStock      int     `json:"available_stock"`
+	InStock             bool    `json:"in_stock"`
 }
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+type CreateCreditRepayment struct {
+	// TerminalID wajib untuk pelunasan cash, uangnya masuk ke laci shift yang open di terminal ini.
+	TerminalID string `json:"terminal_id"`
+	Method     string `json:"method"`
+	Amount     int    `json:"amount"`
+	Reference  string `json:"reference"`
+	Note       string `json:"note"`
+}
+
+type CreditRepayment struct {
+	ID         uint      `json:"id"`
+	CustomerID uint      `json:"customer_id"`
+	ShiftID    *uint     `json:"shift_id,omitempty"`
+	TerminalID string    `json:"terminal_id,omitempty"`
+	Method     string    `json:"method"`
+	Amount     int       `json:"amount"`
+	Reference  string    `json:"reference,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreditAccount struct {
+	CustomerID    uint              `json:"customer_id"`
+	CreditLimit   int               `json:"credit_limit"`
+	CreditBalance int               `json:"credit_balance"`
+	Available     int               `json:"available"`
+	Repayments    []CreditRepayment `json:"repayments"`
+	Pagination    Pagination        `json:"pagination"`
+}
+
+// CreditAging piutang yang belum lunas per umur: 0-30, 31-60 dan > 60 hari.
+type CreditAging struct {
+	AsOf       string          `json:"as_of"`
+	Days0To30  int             `json:"days_0_30"`
+	Days31To60 int             `json:"days_31_60"`
+	Over60     int             `json:"days_over_60"`
+	Total      int             `json:"total"`
+	Customers  []CustomerAging `json:"customers"`
+}
+
+type CustomerAging struct {
+	CustomerID   uint           `json:"customer_id"`
+	CustomerName string         `json:"customer_name"`
+	Days0To30    int            `json:"days_0_30"`
+	Days31To60   int            `json:"days_31_60"`
+	Over60       int            `json:"days_over_60"`
+	Total        int            `json:"total"`
+	Invoices     []AgingInvoice `json:"invoices"`
+}
+
+type AgingInvoice struct {
+	TransactionID uint      `json:"transaction_id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	CreatedAt     time.Time `json:"created_at"`
+	AgeDays       int       `json:"age_days"`
+	Amount        int       `json:"amount"`
+	Outstanding   int       `json:"outstanding"`
+}
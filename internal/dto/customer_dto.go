@@ -0,0 +1,70 @@
+package dto
+
+import "time"
+
+type Customer struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+	Address string `json:"address"`
+	Note    string `json:"note"`
+	// CreditLimit batas kasbon customer, 0 berarti tidak boleh bayar credit.
+	CreditLimit int `json:"credit_limit"`
+	// CustomerGroupID grup harga customer, null berarti harga normal.
+	CustomerGroupID *uint `json:"customer_group_id"`
+}
+
+type CustomerResponse struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Phone           string    `json:"phone"`
+	Email           string    `json:"email"`
+	Address         string    `json:"address"`
+	Note            string    `json:"note"`
+	PointsBalance   int       `json:"points_balance"`
+	CreditLimit     int       `json:"credit_limit"`
+	CreditBalance   int       `json:"credit_balance"`
+	CustomerGroupID *uint     `json:"customer_group_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CustomerFilter Q cari di nama (sebagian, case-insensitive) atau nomor HP.
+// Phone adalah Q yang sudah dinormalisasi seperti nomor HP tersimpan, diisi service.
+type CustomerFilter struct {
+	Q     string
+	Phone string
+	Page  int
+	Limit int
+}
+
+type CustomerList struct {
+	Items      []CustomerResponse `json:"items"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+type CustomerHistory struct {
+	Customer         CustomerResponse `json:"customer"`
+	TransactionCount int              `json:"transaction_count"`
+	LifetimeValue    int              `json:"lifetime_value"`
+	FirstPurchaseAt  *time.Time       `json:"first_purchase_at"`
+	LastPurchaseAt   *time.Time       `json:"last_purchase_at"`
+	Transactions     TransactionList  `json:"transactions"`
+}
+
+type LoyaltyLedger struct {
+	ID            uint      `json:"id"`
+	TransactionID *uint     `json:"transaction_id,omitempty"`
+	Type          string    `json:"type"`
+	Points        int       `json:"points"`
+	BalanceAfter  int       `json:"balance_after"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CustomerPoints struct {
+	CustomerID    uint            `json:"customer_id"`
+	PointsBalance int             `json:"points_balance"`
+	Ledger        []LoyaltyLedger `json:"ledger"`
+	Pagination    Pagination      `json:"pagination"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type CustomerGroup struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type CustomerGroupResponse struct {
+	ID          uint                 `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Prices      []GroupPriceResponse `json:"prices,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// GroupPrice harga grup untuk product atau category. Type "fixed" (value = harga jual)
+// atau "percent" (value = persen potongan dari harga normal, 0-100).
+type GroupPrice struct {
+	ProductID  *uint   `json:"product_id,omitempty"`
+	CategoryID *uint   `json:"category_id,omitempty"`
+	Type       string  `json:"type"`
+	Value      float64 `json:"value"`
+}
+
+type GroupPriceResponse struct {
+	ID              uint      `json:"id"`
+	CustomerGroupID uint      `json:"customer_group_id"`
+	ProductID       *uint     `json:"product_id"`
+	CategoryID      *uint     `json:"category_id"`
+	Type            string    `json:"type"`
+	Value           float64   `json:"value"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
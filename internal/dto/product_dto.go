@@ -3,10 +3,11 @@ package dto
 import "time"
 
 type Product struct {
-	CategoryID *uint  `json:"category_id,omitempty"`
-	Name       string `json:"name"`
-	Price      int    `json:"price"`
-	Stock      int    `json:"stock"`
+	CategoryID *uint       `json:"category_id,omitempty"`
+	Name       string      `json:"name"`
+	Price      int         `json:"price"`
+	Stock      int         `json:"stock"`
+	Tiers      []PriceTier `json:"tiers,omitempty"`
 }
 
 type UpdateProduct struct {
@@ -14,16 +15,25 @@ type UpdateProduct struct {
 	Name       *string `json:"name,omitempty"`
 	Price      *int    `json:"price,omitempty"`
 	Stock      *int    `json:"stock,omitempty"`
+	// Tiers nil berarti tidak diubah, array kosong berarti hapus semua tier.
+	Tiers *[]PriceTier `json:"tiers,omitempty"`
+}
+
+// PriceTier harga grosir: beli >= MinQuantity dapat harga Price per unit.
+type PriceTier struct {
+	MinQuantity int `json:"min_quantity"`
+	Price       int `json:"price"`
 }
 
 type ProductResponse struct {
-	ID         uint      `json:"id"`
-	CategoryID uint      `json:"category_id"`
-	Name       string    `json:"name"`
-	Price      int       `json:"price"`
-	Stock      int       `json:"stock"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint        `json:"id"`
+	CategoryID uint        `json:"category_id"`
+	Name       string      `json:"name"`
+	Price      int         `json:"price"`
+	Stock      int         `json:"stock"`
+	Tiers      []PriceTier `json:"tiers"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
 type ProductDetailResponse struct {
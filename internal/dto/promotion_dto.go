@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// Promotion promo otomatis. Field yang dipakai tergantung Type:
+// buy_x_get_y (BuyQuantity, GetQuantity), bundle_price (BuyQuantity, BundlePrice), percent_off (Percent).
+type Promotion struct {
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	ProductID   *uint      `json:"product_id,omitempty"`
+	CategoryID  *uint      `json:"category_id,omitempty"`
+	BuyQuantity int        `json:"buy_quantity"`
+	GetQuantity int        `json:"get_quantity"`
+	BundlePrice int        `json:"bundle_price"`
+	Percent     float64    `json:"percent"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	// StartTime & EndTime jam berlaku harian "HH:MM" di timezone toko, kosong berarti sepanjang hari.
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Priority  int    `json:"priority"`
+	Stackable bool   `json:"stackable"`
+	// Active default true.
+	Active *bool `json:"active,omitempty"`
+}
+
+type PromotionResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	ProductID   *uint      `json:"product_id"`
+	CategoryID  *uint      `json:"category_id"`
+	BuyQuantity int        `json:"buy_quantity"`
+	GetQuantity int        `json:"get_quantity"`
+	BundlePrice int        `json:"bundle_price"`
+	Percent     float64    `json:"percent"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	StartTime   string     `json:"start_time"`
+	EndTime     string     `json:"end_time"`
+	Priority    int        `json:"priority"`
+	Stackable   bool       `json:"stackable"`
+	Active      bool       `json:"active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
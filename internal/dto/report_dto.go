@@ -1,10 +1,27 @@
 package dto
 
 type Report struct {
-	ReportRange      string        `json:"repor_range"`
-	TotalRevenue     int           `json:"total_revenue"`
-	TotalTransaction int           `json:"total_transaction"`
-	BestProduct      []BestProduct `json:"best_product"`
+	ReportRange      string           `json:"repor_range"`
+	GrossRevenue     int              `json:"gross_revenue"`
+	TotalDiscount    int              `json:"total_discount"`
+	TotalRevenue     int              `json:"total_revenue"`
+	TotalReturn      int              `json:"total_return"`
+	NetRevenue       int              `json:"net_revenue"`
+	TotalRounding    int              `json:"total_rounding"`
+	TotalService     int              `json:"total_service_charge"`
+	TotalTax         int              `json:"total_tax"`
+	TotalCashIn      int              `json:"total_cash_in"`
+	TotalCashOut     int              `json:"total_cash_out"`
+	Taxes            []TaxSummary     `json:"taxes"`
+	TotalTransaction int              `json:"total_transaction"`
+	BestProduct      []BestProduct    `json:"best_product"`
+	Payments         []PaymentSummary `json:"payments"`
+}
+
+type PaymentSummary struct {
+	Method           string `json:"method"`
+	TransactionCount int    `json:"transaction_count"`
+	Amount           int    `json:"amount"`
 }
 
 type BestProduct struct {
@@ -12,3 +29,11 @@ type BestProduct struct {
 	Quantity int    `json:"quantity"`
 	Subtotal int    `json:"subtotal"`
 }
+
+type TaxSummary struct {
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate"`
+	Inclusive bool    `json:"inclusive"`
+	TaxBase   int     `json:"tax_base"`
+	TaxAmount int     `json:"tax_amount"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type CreateReturn struct {
+	Reason       string       `json:"reason"`
+	Items        []ReturnItem `json:"items"`
+	RefundMethod string       `json:"refund_method,omitempty"`
+	TerminalID   string       `json:"terminal_id,omitempty"`
+}
+
+type ReturnItem struct {
+	TransactionDetailID uint `json:"transaction_detail_id"`
+	Quantity            int  `json:"quantity"`
+}
+
+type TransactionReturn struct {
+	ID            uint                    `json:"id"`
+	TransactionID uint                    `json:"transaction_id"`
+	Reason        string                  `json:"reason"`
+	RefundAmount  int                     `json:"refund_amount"`
+	PointsAmount  int                     `json:"points_amount"`
+	CreditAmount  int                     `json:"credit_amount"`
+	RefundMethod  string                  `json:"refund_method"`
+	ShiftID       *uint                   `json:"shift_id,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+	Items         []TransactionReturnItem `json:"items"`
+}
+
+type TransactionReturnItem struct {
+	ID                  uint   `json:"id"`
+	ReturnID            uint   `json:"return_id"`
+	TransactionDetailID uint   `json:"transaction_detail_id"`
+	ProductID           uint   `json:"product_id"`
+	ProductName         string `json:"product_name"`
+	Quantity            int    `json:"quantity"`
+	RefundAmount        int    `json:"refund_amount"`
+}
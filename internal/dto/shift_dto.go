@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+type OpenShift struct {
+	CashierName  string `json:"cashier_name"`
+	TerminalID   string `json:"terminal_id"`
+	OpeningFloat int    `json:"opening_float"`
+}
+
+type CloseShift struct {
+	CountedCash *int   `json:"counted_cash"`
+	Note        string `json:"note"`
+}
+
+type Shift struct {
+	ID             uint          `json:"id"`
+	CashierName    string        `json:"cashier_name"`
+	TerminalID     string        `json:"terminal_id"`
+	OpeningFloat   int           `json:"opening_float"`
+	Status         string        `json:"status"`
+	ExpectedCash   *int          `json:"expected_cash"`
+	CountedCash    *int          `json:"counted_cash"`
+	CashDifference *int          `json:"cash_difference"`
+	ClosingNote    string        `json:"closing_note,omitempty"`
+	OpenedAt       time.Time     `json:"opened_at"`
+	ClosedAt       *time.Time    `json:"closed_at,omitempty"`
+	Summary        *ShiftSummary `json:"summary,omitempty"`
+}
+
+// ShiftSummary rekap shift.
+// ExpectedCash = opening float + cash diterima - kembalian - refund cash + cash in - cash out.
+type ShiftSummary struct {
+	TransactionCount    int              `json:"transaction_count"`
+	SalesAmount         int              `json:"sales_amount"`
+	CashReceived        int              `json:"cash_received"`
+	ChangeGiven         int              `json:"change_given"`
+	CashRefunded        int              `json:"cash_refunded"`
+	CashIn              int              `json:"cash_in"`
+	CashOut             int              `json:"cash_out"`
+	CreditRepaymentCash int              `json:"credit_repayment_cash"`
+	ExpectedCash        int              `json:"expected_cash"`
+	Payments            []PaymentSummary `json:"payments"`
+	Refunds             []RefundSummary  `json:"refunds"`
+}
+
+type RefundSummary struct {
+	Method string `json:"method"`
+	Count  int    `json:"count"`
+	Amount int    `json:"amount"`
+}
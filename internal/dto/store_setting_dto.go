@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type StoreSetting struct {
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	Phone         string `json:"phone"`
+	NPWP          string `json:"npwp"`
+	ReceiptFooter string `json:"receipt_footer"`
+	// InvoiceTemplate html/template untuk invoice A4, kosong berarti pakai template bawaan.
+	InvoiceTemplate string `json:"invoice_template"`
+	// InvoicePDFTemplate text/template untuk invoice PDF (teks monospace, baris diawali "**" dicetak tebal).
+	// PDF tidak dirender dari InvoiceTemplate karena HTML butuh browser engine; kosong berarti layout bawaan.
+	InvoicePDFTemplate string `json:"invoice_pdf_template"`
+}
+
+type StoreSettingResponse struct {
+	Name               string    `json:"name"`
+	Address            string    `json:"address"`
+	Phone              string    `json:"phone"`
+	NPWP               string    `json:"npwp"`
+	ReceiptFooter      string    `json:"receipt_footer"`
+	InvoiceTemplate    string    `json:"invoice_template"`
+	InvoicePDFTemplate string    `json:"invoice_pdf_template"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type TaxRate struct {
+	Name       string  `json:"name"`
+	Rate       float64 `json:"rate"`
+	Inclusive  bool    `json:"inclusive"`
+	CategoryID *uint   `json:"category_id,omitempty"`
+	ProductID  *uint   `json:"product_id,omitempty"`
+}
+
+type TaxRateResponse struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	Rate       float64   `json:"rate"`
+	Inclusive  bool      `json:"inclusive"`
+	CategoryID *uint     `json:"category_id"`
+	ProductID  *uint     `json:"product_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
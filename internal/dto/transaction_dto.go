@@ -3,17 +3,102 @@ package dto
 import "time"
 
 type Transaction struct {
-	ID        uint                `json:"id"`
-	Total     int                 `json:"total"`
-	CreatedAt time.Time           `json:"created_at"`
-	Details   []TransactionDetail `json:"details"`
+	ID                  uint                 `json:"id"`
+	InvoiceNumber       string               `json:"invoice_number"`
+	TerminalID          string               `json:"terminal_id,omitempty"`
+	ShiftID             *uint                `json:"shift_id,omitempty"`
+	CustomerID          *uint                `json:"customer_id,omitempty"`
+	PointsEarned        int                  `json:"points_earned"`
+	PointsRedeemed      int                  `json:"points_redeemed"`
+	Subtotal            int                  `json:"subtotal"`
+	DiscountAmount      int                  `json:"discount_amount"`
+	CartDiscountAmount  int                  `json:"cart_discount_amount"`
+	PromotionAmount     int                  `json:"promotion_amount"`
+	VoucherCode         string               `json:"voucher_code,omitempty"`
+	VoucherAmount       int                  `json:"voucher_amount"`
+	ServiceChargeAmount int                  `json:"service_charge_amount"`
+	TaxBaseAmount       int                  `json:"tax_base_amount"`
+	TaxAmount           int                  `json:"tax_amount"`
+	Total               int                  `json:"total"`
+	RoundingAmount      int                  `json:"rounding_amount"`
+	PaidAmount          int                  `json:"paid_amount"`
+	ChangeAmount        int                  `json:"change_amount"`
+	Status              string               `json:"status"`
+	StatusReason        string               `json:"status_reason,omitempty"`
+	StatusUpdatedAt     *time.Time           `json:"status_updated_at,omitempty"`
+	RefundMethod        string               `json:"refund_method,omitempty"`
+	RefundAmount        int                  `json:"refund_amount,omitempty"`
+	RefundShiftID       *uint                `json:"refund_shift_id,omitempty"`
+	CreatedAt           time.Time            `json:"created_at"`
+	Details             []TransactionDetail  `json:"details"`
+	Payments            []TransactionPayment `json:"payments"`
+}
+
+type TransactionPayment struct {
+	ID        uint   `json:"id"`
+	Method    string `json:"method"`
+	Amount    int    `json:"amount"`
+	Reference string `json:"reference,omitempty"`
+}
+
+type ReverseTransaction struct {
+	Reason string `json:"reason"`
+	// RefundMethod cara uang dikembalikan, default cash (keluar dari laci shift yang open).
+	RefundMethod string `json:"refund_method,omitempty"`
+	// TerminalID terminal yang mengeluarkan refund, default terminal transaksi.
+	TerminalID string `json:"terminal_id,omitempty"`
 }
 
 type TransactionDetail struct {
-	ID            uint   `json:"id"`
-	TransactionID uint   `json:"transaction_id"`
-	ProductID     uint   `json:"product_id"`
-	ProductName   string `json:"product_name"`
-	Quantity      int    `json:"quantity"`
-	Subtotal      int    `json:"subtotal"`
+	ID                  uint    `json:"id"`
+	TransactionID       uint    `json:"transaction_id"`
+	ProductID           uint    `json:"product_id"`
+	ProductName         string  `json:"product_name"`
+	CategoryID          uint    `json:"category_id"`
+	CategoryName        string  `json:"category_name"`
+	BasePrice           int     `json:"base_price"`
+	UnitPrice           int     `json:"unit_price"`
+	PriceListID         *uint   `json:"price_list_id,omitempty"`
+	PriceListName       string  `json:"price_list_name,omitempty"`
+	Quantity            int     `json:"quantity"`
+	ReturnedQuantity    int     `json:"returned_quantity"`
+	DiscountAmount      int     `json:"discount_amount"`
+	CartDiscountAmount  int     `json:"cart_discount_amount"`
+	PromotionAmount     int     `json:"promotion_amount"`
+	PromotionName       string  `json:"promotion_name,omitempty"`
+	VoucherAmount       int     `json:"voucher_amount"`
+	Subtotal            int     `json:"subtotal"`
+	ServiceChargeAmount int     `json:"service_charge_amount"`
+	TaxName             string  `json:"tax_name,omitempty"`
+	TaxRate             float64 `json:"tax_rate"`
+	TaxInclusive        bool    `json:"tax_inclusive"`
+	TaxBaseAmount       int     `json:"tax_base_amount"`
+	TaxAmount           int     `json:"tax_amount"`
+}
+
+type TransactionFilter struct {
+	StartDate string
+	EndDate   string
+	// Invoice cari sebagian nomor invoice (case-insensitive)
+	Invoice    string
+	MinAmount  *int
+	MaxAmount  *int
+	ProductID  uint
+	ShiftID    uint
+	CustomerID uint
+	Status     string
+	Page       int
+	Limit      int
+}
+
+type TransactionList struct {
+	Items      []Transaction `json:"items"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+type Pagination struct {
+	Page      int   `json:"page"`
+	Limit     int   `json:"limit"`
+	TotalData int64 `json:"total_data"`
+	TotalPage int   `json:"total_page"`
 }
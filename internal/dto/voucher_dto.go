@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// Voucher type "fixed" (value rupiah) atau "percent" (value 0-100, dibatasi MaxDiscount kalau > 0).
+// UsageLimit & PerCustomerLimit 0 berarti tanpa batas, CategoryIDs kosong berarti semua category.
+type Voucher struct {
+	Code             string     `json:"code"`
+	Description      string     `json:"description"`
+	Type             string     `json:"type"`
+	Value            float64    `json:"value"`
+	MaxDiscount      int        `json:"max_discount"`
+	MinSpend         int        `json:"min_spend"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	UsageLimit       int        `json:"usage_limit"`
+	PerCustomerLimit int        `json:"per_customer_limit"`
+	CategoryIDs      []uint     `json:"category_ids"`
+	// Active default true.
+	Active *bool `json:"active,omitempty"`
+}
+
+type VoucherResponse struct {
+	ID               uint       `json:"id"`
+	Code             string     `json:"code"`
+	Description      string     `json:"description"`
+	Type             string     `json:"type"`
+	Value            float64    `json:"value"`
+	MaxDiscount      int        `json:"max_discount"`
+	MinSpend         int        `json:"min_spend"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	UsageLimit       int        `json:"usage_limit"`
+	PerCustomerLimit int        `json:"per_customer_limit"`
+	UsedCount        int        `json:"used_count"`
+	CategoryIDs      []uint     `json:"category_ids"`
+	Active           bool       `json:"active"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
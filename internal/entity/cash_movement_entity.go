@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+const (
+	CashDirectionIn  = "in"
+	CashDirectionOut = "out"
+)
+
+// CashMovement uang masuk/keluar laci di luar penjualan, misal bayar kurir atau tambah uang kembalian.
+type CashMovement struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	ShiftID     uint      `gorm:"not null"`
+	Direction   string    `gorm:"type:text;not null"`
+	Amount      int       `gorm:"not null"`
+	Reason      string    `gorm:"type:text;not null"`
+	PerformedBy string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// CreditRepayment pelunasan piutang (kasbon) customer.
+type CreditRepayment struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	CustomerID uint      `gorm:"not null"`
+	ShiftID    *uint     `gorm:"column:shift_id"`
+	TerminalID string    `gorm:"type:text;not null"`
+	Method     string    `gorm:"type:text;not null"`
+	Amount     int       `gorm:"not null"`
+	Reference  string    `gorm:"type:text;not null"`
+	Note       string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// CreditSale bagian transaksi completed yang dibayar credit, bahan aging report.
+type CreditSale struct {
+	CustomerID    uint      `gorm:"column:customer_id"`
+	CustomerName  string    `gorm:"column:customer_name"`
+	TransactionID uint      `gorm:"column:transaction_id"`
+	InvoiceNumber string    `gorm:"column:invoice_number"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	Amount        int       `gorm:"column:amount"`
+}
+
+type CreditRepaid struct {
+	CustomerID uint `gorm:"column:customer_id"`
+	Amount     int  `gorm:"column:amount"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type Customer struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement"`
+	Name    string `gorm:"type:text;not null"`
+	Phone   string `gorm:"type:text;not null"`
+	Email   string `gorm:"type:text;not null"`
+	Address string `gorm:"type:text;not null"`
+	Note    string `gorm:"type:text;not null"`
+	// CustomerGroupID grup member, menentukan price list saat checkout.
+	CustomerGroupID *uint `gorm:"column:customer_group_id"`
+	// PointsBalance hanya diubah lewat LoyaltyRepository.Post supaya selalu sama dengan ledger.
+	PointsBalance int `gorm:"not null;default:0"`
+	CreditLimit   int `gorm:"not null;default:0"`
+	// CreditBalance piutang berjalan, hanya diubah lewat CreditRepository.
+	CreditBalance int       `gorm:"not null;default:0"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// CustomerStats rekap belanja customer dari transaksi yang masih completed (setelah retur).
+type CustomerStats struct {
+	TransactionCount int        `gorm:"column:transaction_count"`
+	LifetimeValue    int        `gorm:"column:lifetime_value"`
+	FirstPurchaseAt  *time.Time `gorm:"column:first_purchase_at"`
+	LastPurchaseAt   *time.Time `gorm:"column:last_purchase_at"`
+}
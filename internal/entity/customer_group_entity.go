@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+const (
+	GroupPriceFixed   = "fixed"
+	GroupPricePercent = "percent"
+)
+
+// CustomerGroup grup member (mis. grosir, karyawan) yang punya price list sendiri.
+type CustomerGroup struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	Name        string    `gorm:"type:text;not null"`
+	Description string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// GroupPrice harga khusus grup untuk satu product atau satu category.
+// Type fixed: Value = harga jual, percent: Value = persen potongan dari harga normal.
+type GroupPrice struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement"`
+	CustomerGroupID uint      `gorm:"not null"`
+	ProductID       *uint     `gorm:"column:product_id"`
+	CategoryID      *uint     `gorm:"column:category_id"`
+	Type            string    `gorm:"type:text;not null"`
+	Value           float64   `gorm:"type:numeric(12,2);not null"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type IdempotencyKey struct {
+	Key           string    `gorm:"primaryKey;type:text"`
+	RequestHash   string    `gorm:"type:text;not null"`
+	TransactionID *uint     `gorm:"column:transaction_id"`
+	ResponseBody  string    `gorm:"type:jsonb;default:null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
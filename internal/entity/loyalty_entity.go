@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+const (
+	LoyaltyEarn     = "earn"
+	LoyaltyRedeem   = "redeem"
+	LoyaltyReversal = "reversal"
+)
+
+// LoyaltyLedger mutasi poin customer. Points positif = bertambah, negatif = berkurang.
+type LoyaltyLedger struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	CustomerID    uint      `gorm:"not null"`
+	TransactionID *uint     `gorm:"column:transaction_id"`
+	Type          string    `gorm:"type:text;not null"`
+	Points        int       `gorm:"not null"`
+	BalanceAfter  int       `gorm:"not null"`
+	Note          string    `gorm:"type:text;not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	PaymentMethodCash       = "cash"
+	PaymentMethodQRIS       = "qris"
+	PaymentMethodDebitCard  = "debit_card"
+	PaymentMethodCreditCard = "credit_card"
+	PaymentMethodTransfer   = "transfer"
+	// PaymentMethodCredit dicatat sebagai piutang customer (kasbon), dilunasi lewat credit repayment.
+	PaymentMethodCredit = "credit"
+	// PaymentMethodPoints penukaran poin loyalty, dibuat server dari redeem_points (bukan input kasir).
+	PaymentMethodPoints = "points"
+)
+
+type TransactionPayment struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	TransactionID uint      `gorm:"not null"`
+	Method        string    `gorm:"type:text;not null"`
+	Amount        int       `gorm:"not null"`
+	Reference     string    `gorm:"type:text;not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
@@ -11,3 +11,12 @@ type Product struct {
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 }
+
+// ProductPriceTier harga per unit kalau quantity satu product di keranjang >= MinQuantity.
+type ProductPriceTier struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	ProductID   uint      `gorm:"not null"`
+	MinQuantity int       `gorm:"not null"`
+	Price       int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
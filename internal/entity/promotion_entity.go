@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	PromoBuyXGetY    = "buy_x_get_y"
+	PromoBundlePrice = "bundle_price"
+	PromoPercentOff  = "percent_off"
+)
+
+// Promotion promo otomatis di checkout untuk satu product atau satu category.
+// Promo dengan Priority lebih besar dievaluasi duluan; promo non-stackable tidak bisa
+// digabung dengan promo lain di baris yang sama.
+type Promotion struct {
+	ID          uint    `gorm:"primaryKey;autoIncrement"`
+	Name        string  `gorm:"type:text;not null"`
+	Type        string  `gorm:"type:text;not null"`
+	ProductID   *uint   `gorm:"column:product_id"`
+	CategoryID  *uint   `gorm:"column:category_id"`
+	BuyQuantity int     `gorm:"not null"`
+	GetQuantity int     `gorm:"not null"`
+	BundlePrice int     `gorm:"not null"`
+	Percent     float64 `gorm:"type:numeric(5,2);not null"`
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	// StartTime & EndTime jam berlaku harian "HH:MM", kosong berarti sepanjang hari.
+	StartTime string    `gorm:"type:text;not null"`
+	EndTime   string    `gorm:"type:text;not null"`
+	Priority  int       `gorm:"not null"`
+	Stackable bool      `gorm:"not null"`
+	Active    bool      `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
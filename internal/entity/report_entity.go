@@ -1,9 +1,26 @@
 package entity
 
 type Report struct {
-	TotalRevenue     int           `gorm:"column:total_revenue"`
-	TotalTransaction int           `gorm:"column:total_transaction"`
-	BestProduct      []BestProduct `gorm:"-"`
+	GrossRevenue     int              `gorm:"column:gross_revenue"`
+	TotalDiscount    int              `gorm:"column:total_discount"`
+	TotalRevenue     int              `gorm:"column:total_revenue"`
+	TotalTransaction int              `gorm:"column:total_transaction"`
+	TotalReturn      int              `gorm:"column:total_return"`
+	TotalChange      int              `gorm:"column:total_change"`
+	TotalRounding    int              `gorm:"column:total_rounding"`
+	TotalService     int              `gorm:"column:total_service_charge"`
+	TotalTax         int              `gorm:"column:total_tax"`
+	TotalCashIn      int              `gorm:"column:total_cash_in"`
+	TotalCashOut     int              `gorm:"column:total_cash_out"`
+	Taxes            []TaxSummary     `gorm:"-"`
+	BestProduct      []BestProduct    `gorm:"-"`
+	Payments         []PaymentSummary `gorm:"-"`
+}
+
+type PaymentSummary struct {
+	Method           string `gorm:"column:method"`
+	TransactionCount int    `gorm:"column:transaction_count"`
+	Amount           int    `gorm:"column:amount"`
 }
 
 type BestProduct struct {
@@ -11,3 +28,11 @@ type BestProduct struct {
 	Quantity int    `gorm:"column:quantity"`
 	Subtotal int    `gorm:"column:subtotal"`
 }
+
+type TaxSummary struct {
+	Name      string  `gorm:"column:name"`
+	Rate      float64 `gorm:"column:rate"`
+	Inclusive bool    `gorm:"column:inclusive"`
+	TaxBase   int     `gorm:"column:tax_base"`
+	TaxAmount int     `gorm:"column:tax_amount"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type TransactionReturn struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	TransactionID uint      `gorm:"not null"`
+	Reason        string    `gorm:"type:text;not null"`
+	RefundAmount  int       `gorm:"not null"`
+	PointsAmount  int       `gorm:"not null"`
+	CreditAmount  int       `gorm:"not null"`
+	RefundMethod  string    `gorm:"type:text;not null"`
+	ShiftID       *uint     `gorm:"column:shift_id"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+type TransactionReturnDetail struct {
+	ID                  uint      `gorm:"primaryKey;autoIncrement"`
+	ReturnID            uint      `gorm:"not null"`
+	TransactionDetailID uint      `gorm:"not null"`
+	ProductID           uint      `gorm:"not null"`
+	Quantity            int       `gorm:"not null"`
+	RefundAmount        int       `gorm:"not null"`
+	CreatedAt           time.Time `gorm:"autoCreateTime"`
+}
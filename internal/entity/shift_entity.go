@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+// Shift sesi kasir di satu terminal, dari buka laci (opening float) sampai hitung uang saat tutup.
+type Shift struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement"`
+	CashierName    string     `gorm:"type:text;not null"`
+	TerminalID     string     `gorm:"type:text;not null"`
+	OpeningFloat   int        `gorm:"not null"`
+	Status         string     `gorm:"type:text;not null;default:open"`
+	ExpectedCash   *int       `gorm:"column:expected_cash"`
+	CountedCash    *int       `gorm:"column:counted_cash"`
+	CashDifference *int       `gorm:"column:cash_difference"`
+	ClosingNote    string     `gorm:"type:text;not null"`
+	OpenedAt       time.Time  `gorm:"autoCreateTime"`
+	ClosedAt       *time.Time `gorm:"column:closed_at"`
+}
+
+// ShiftSummary rekap uang yang masuk & keluar selama shift.
+type ShiftSummary struct {
+	TransactionCount int `gorm:"column:transaction_count"`
+	SalesAmount      int `gorm:"column:sales_amount"`
+	CashReceived     int `gorm:"column:cash_received"`
+	ChangeGiven      int `gorm:"column:change_given"`
+	CashIn           int `gorm:"column:cash_in"`
+	CashOut          int `gorm:"column:cash_out"`
+	// CreditRepaymentCash pelunasan kasbon tunai yang diterima di shift ini.
+	CreditRepaymentCash int `gorm:"column:credit_repayment_cash"`
+	Payments            []PaymentSummary
+	Refunds             []RefundSummary
+}
+
+type RefundSummary struct {
+	Method string `gorm:"column:method"`
+	Count  int    `gorm:"column:refund_count"`
+	Amount int    `gorm:"column:amount"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// StoreSetting identitas toko, hanya ada satu baris (ID = 1).
+type StoreSetting struct {
+	ID                 uint      `gorm:"primaryKey"`
+	Name               string    `gorm:"type:text;not null"`
+	Address            string    `gorm:"type:text;not null"`
+	Phone              string    `gorm:"type:text;not null"`
+	NPWP               string    `gorm:"column:npwp;type:text;not null"`
+	ReceiptFooter      string    `gorm:"type:text;not null"`
+	InvoiceTemplate    string    `gorm:"type:text;not null"`
+	InvoicePDFTemplate string    `gorm:"column:invoice_pdf_template;type:text;not null"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// TaxRate tarif pajak (mis. PPN). Tanpa category/product berarti tarif default.
+type TaxRate struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	Name       string    `gorm:"type:text;not null"`
+	Rate       float64   `gorm:"type:numeric(5,2);not null"`
+	Inclusive  bool      `gorm:"not null"`
+	CategoryID *uint     `gorm:"column:category_id"`
+	ProductID  *uint     `gorm:"column:product_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
@@ -2,17 +2,68 @@ package entity
 
 import "time"
 
+const (
+	TrxStatusCompleted = "completed"
+	TrxStatusVoided    = "voided"
+	TrxStatusRefunded  = "refunded"
+)
+
 type Transaction struct {
-	ID          uint      `gorm:"primaryKey;autoIncrement"`
-	TotalAmount int       `gorm:"not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ID                  uint       `gorm:"primaryKey;autoIncrement"`
+	InvoiceNumber       string     `gorm:"type:text;not null"`
+	TerminalID          string     `gorm:"type:text;not null"`
+	ShiftID             *uint      `gorm:"column:shift_id"`
+	CustomerID          *uint      `gorm:"column:customer_id"`
+	PointsEarned        int        `gorm:"not null"`
+	PointsRedeemed      int        `gorm:"not null"`
+	SubtotalAmount      int        `gorm:"not null"`
+	DiscountAmount      int        `gorm:"not null"`
+	CartDiscountAmount  int        `gorm:"not null"`
+	PromotionAmount     int        `gorm:"not null"`
+	VoucherID           *uint      `gorm:"column:voucher_id"`
+	VoucherCode         string     `gorm:"type:text;not null"`
+	VoucherAmount       int        `gorm:"not null"`
+	ServiceChargeAmount int        `gorm:"not null"`
+	TaxBaseAmount       int        `gorm:"not null"`
+	TaxAmount           int        `gorm:"not null"`
+	TotalAmount         int        `gorm:"not null"`
+	RoundingAmount      int        `gorm:"not null"`
+	PaidAmount          int        `gorm:"not null"`
+	ChangeAmount        int        `gorm:"not null"`
+	Status              string     `gorm:"type:text;not null;default:completed"`
+	StatusReason        string     `gorm:"type:text;not null"`
+	StatusUpdatedAt     *time.Time `gorm:"column:status_updated_at"`
+	RefundShiftID       *uint      `gorm:"column:refund_shift_id"`
+	RefundMethod        string     `gorm:"type:text;not null"`
+	RefundAmount        int        `gorm:"not null"`
+	CreditReleased      int        `gorm:"not null"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime"`
 }
 
 type TransactionDetail struct {
-	ID            uint      `gorm:"primaryKey;autoIncrement"`
-	TransactionID uint      `gorm:"not null"`
-	ProductID     uint      `gorm:"not null"`
-	Quantity      int       `gorm:"not null"`
-	Subtotal      int       `gorm:"not null"`
-	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	ID                  uint      `gorm:"primaryKey;autoIncrement"`
+	TransactionID       uint      `gorm:"not null"`
+	ProductID           uint      `gorm:"not null"`
+	ProductName         string    `gorm:"type:text;not null"`
+	CategoryID          uint      `gorm:"column:category_id"`
+	CategoryName        string    `gorm:"type:text;not null"`
+	BasePrice           int       `gorm:"not null"`
+	UnitPrice           int       `gorm:"not null"`
+	PriceListID         *uint     `gorm:"column:price_list_id"`
+	PriceListName       string    `gorm:"type:text;not null"`
+	Quantity            int       `gorm:"not null"`
+	DiscountAmount      int       `gorm:"not null"`
+	CartDiscountAmount  int       `gorm:"not null"`
+	PromotionAmount     int       `gorm:"not null"`
+	PromotionName       string    `gorm:"type:text;not null"`
+	VoucherAmount       int       `gorm:"not null"`
+	Subtotal            int       `gorm:"not null"`
+	ServiceChargeAmount int       `gorm:"not null"`
+	TaxName             string    `gorm:"type:text;not null"`
+	TaxRate             float64   `gorm:"type:numeric(5,2);not null"`
+	TaxInclusive        bool      `gorm:"not null"`
+	TaxBaseAmount       int       `gorm:"not null"`
+	TaxAmount           int       `gorm:"not null"`
+	ReturnedQuantity    int       `gorm:"not null;default:0"`
+	CreatedAt           time.Time `gorm:"autoCreateTime"`
 }
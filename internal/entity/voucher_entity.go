@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+const (
+	VoucherFixed   = "fixed"
+	VoucherPercent = "percent"
+
+	RedemptionRedeemed = "redeemed"
+	RedemptionReleased = "released"
+)
+
+// Voucher kode promo yang dimasukkan kasir saat checkout.
+type Voucher struct {
+	ID          uint    `gorm:"primaryKey;autoIncrement"`
+	Code        string  `gorm:"type:text;not null"`
+	Description string  `gorm:"type:text;not null"`
+	Type        string  `gorm:"type:text;not null"`
+	Value       float64 `gorm:"type:numeric(12,2);not null"`
+	// MaxDiscount batas potongan voucher percent, 0 berarti tanpa batas.
+	MaxDiscount int `gorm:"not null"`
+	MinSpend    int `gorm:"not null"`
+	ExpiresAt   *time.Time
+	// UsageLimit & PerCustomerLimit 0 berarti tanpa batas.
+	UsageLimit       int `gorm:"not null"`
+	PerCustomerLimit int `gorm:"not null"`
+	// UsedCount hanya diubah lewat VoucherRepository.Redeem/Release.
+	UsedCount int       `gorm:"not null"`
+	Active    bool      `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// CategoryIDs category yang boleh dipotong voucher, kosong berarti semua.
+	CategoryIDs []uint `gorm:"-"`
+}
+
+type VoucherCategory struct {
+	VoucherID  uint `gorm:"primaryKey"`
+	CategoryID uint `gorm:"primaryKey"`
+}
+
+type VoucherRedemption struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement"`
+	VoucherID     uint       `gorm:"not null"`
+	TransactionID uint       `gorm:"not null"`
+	CustomerID    *uint      `gorm:"column:customer_id"`
+	Amount        int        `gorm:"not null"`
+	Status        string     `gorm:"type:text;not null;default:redeemed"`
+	ReleasedAt    *time.Time `gorm:"column:released_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+}
@@ -33,6 +33,20 @@ func ParseDateQuery(c *fiber.Ctx, key string) (string, error) {
 	return raw, nil
 }
 
+func ParseIntQuery(c *fiber.Ctx, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return nil, fiber.ErrBadRequest
+	}
+
+	return &n, nil
+}
+
 func WriteServiceError(ctx *fiber.Ctx, err error) error {
 	appErr, ok := err.(*service.AppError)
 	if !ok {
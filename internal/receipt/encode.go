@@ -0,0 +1,65 @@
+package receipt
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ESC/POS command yang dipakai, didukung hampir semua printer thermal.
+var (
+	escInit      = []byte{0x1b, '@'}
+	escAlignLeft = []byte{0x1b, 'a', 0}
+	escAlignMid  = []byte{0x1b, 'a', 1}
+	escBoldOn    = []byte{0x1b, 'E', 1}
+	escBoldOff   = []byte{0x1b, 'E', 0}
+	// feed beberapa baris lalu partial cut
+	escFeedCut = []byte{0x1d, 'V', 66, 3}
+)
+
+func encodeText(lines []line, cols int) []byte {
+	var b bytes.Buffer
+	for _, l := range lines {
+		if l.align == alignCenter {
+			b.WriteString(centerText(l.text, cols))
+		} else {
+			b.WriteString(l.text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+func encodeESCPOS(lines []line) []byte {
+	var b bytes.Buffer
+	b.Write(escInit)
+
+	for _, l := range lines {
+		if l.align == alignCenter {
+			b.Write(escAlignMid)
+		}
+		if l.bold {
+			b.Write(escBoldOn)
+		}
+
+		b.WriteString(l.text)
+		b.WriteByte('\n')
+
+		if l.bold {
+			b.Write(escBoldOff)
+		}
+		if l.align == alignCenter {
+			b.Write(escAlignLeft)
+		}
+	}
+
+	b.Write(escFeedCut)
+	return b.Bytes()
+}
+
+func centerText(s string, cols int) string {
+	pad := (cols - len(s)) / 2
+	if pad <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", pad) + s
+}
@@ -0,0 +1,218 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"kasir-api/internal/dto"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	InvoiceFormatHTML = "html"
+	InvoiceFormatPDF  = "pdf"
+)
+
+// TaxLine rincian pajak per tarif.
+type TaxLine struct {
+	Name      string
+	Rate      float64
+	Inclusive bool
+	Base      int
+	Amount    int
+}
+
+// Invoice data yang tersedia di template invoice.
+type Invoice struct {
+	Store       Store
+	Transaction dto.Transaction
+	Taxes       []TaxLine
+	// IssuedAt waktu transaksi di timezone toko.
+	IssuedAt time.Time
+	// PayableAmount total yang harus dibayar (grand total + pembulatan).
+	PayableAmount int
+}
+
+// Label nama pajak + tarif, misal "PPN 11%" atau "PB1 10% (termasuk)".
+func (t TaxLine) Label() string {
+	label := t.Name + " " + percentLabel(t.Rate)
+	if t.Inclusive {
+		label += " (termasuk)"
+	}
+	return label
+}
+
+func NewInvoice(store Store, trx dto.Transaction, loc *time.Location) Invoice {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	return Invoice{
+		Store:         store,
+		Transaction:   trx,
+		Taxes:         taxBreakdown(trx.Details),
+		IssuedAt:      trx.CreatedAt.In(loc),
+		PayableAmount: trx.Total + trx.RoundingAmount,
+	}
+}
+
+func taxBreakdown(details []dto.TransactionDetail) []TaxLine {
+	type key struct {
+		name      string
+		rate      float64
+		inclusive bool
+	}
+
+	byKey := map[key]*TaxLine{}
+	var out []TaxLine
+	var keys []key
+	for _, d := range details {
+		if d.TaxAmount == 0 && d.TaxRate == 0 {
+			continue
+		}
+
+		k := key{d.TaxName, d.TaxRate, d.TaxInclusive}
+		if _, ok := byKey[k]; !ok {
+			byKey[k] = &TaxLine{Name: d.TaxName, Rate: d.TaxRate, Inclusive: d.TaxInclusive}
+			keys = append(keys, k)
+		}
+		byKey[k].Base += d.TaxBaseAmount
+		byKey[k].Amount += d.TaxAmount
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+	for _, k := range keys {
+		out = append(out, *byKey[k])
+	}
+	return out
+}
+
+var invoiceFuncs = template.FuncMap{
+	"money":   money,
+	"payment": paymentLabel,
+	"date": func(t time.Time) string {
+		return t.Format("02-01-2006 15:04")
+	},
+	"percent": percentLabel,
+	"add":     func(a, b int) int { return a + b },
+}
+
+// percentLabel format tarif tanpa nol di belakang, misal 11 -> "11%", 2.5 -> "2.5%".
+func percentLabel(rate float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".") + "%"
+}
+
+// ParseInvoiceTemplate parse template invoice custom. Template kosong berarti template bawaan.
+func ParseInvoiceTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = DefaultInvoiceTemplate
+	}
+	return template.New("invoice").Funcs(invoiceFuncs).Parse(text)
+}
+
+func RenderInvoiceHTML(tmplText string, inv Invoice) ([]byte, error) {
+	tmpl, err := ParseInvoiceTemplate(tmplText)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, inv); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// DefaultInvoiceTemplate template invoice A4 bawaan, dipakai kalau store setting belum punya template.
+const DefaultInvoiceTemplate = `<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Transaction.InvoiceNumber}}</title>
+<style>
+  @page { size: A4; margin: 20mm; }
+  body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; color: #222; }
+  header { display: flex; justify-content: space-between; border-bottom: 2px solid #222; padding-bottom: 8px; }
+  h1 { margin: 0; font-size: 18pt; }
+  table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+  th, td { padding: 4px 6px; border-bottom: 1px solid #ddd; text-align: left; }
+  .num { text-align: right; }
+  .total td { font-weight: bold; border-top: 2px solid #222; }
+  .void { color: #c00; font-weight: bold; }
+</style>
+</head>
+<body>
+<header>
+  <div>
+    <h1>{{.Store.Name}}</h1>
+    <div>{{.Store.Address}}</div>
+    {{if .Store.Phone}}<div>Telp. {{.Store.Phone}}</div>{{end}}
+    {{if .Store.NPWP}}<div>NPWP {{.Store.NPWP}}</div>{{end}}
+  </div>
+  <div class="num">
+    <h1>INVOICE</h1>
+    <div>{{.Transaction.InvoiceNumber}}</div>
+    <div>{{date .IssuedAt}}</div>
+    {{if ne .Transaction.Status "completed"}}<div class="void">{{.Transaction.Status}}</div>{{end}}
+  </div>
+</header>
+
+<table>
+  <thead>
+    <tr><th>Produk</th><th class="num">Qty</th><th class="num">Harga</th><th class="num">Diskon</th><th class="num">Jumlah</th></tr>
+  </thead>
+  <tbody>
+  {{range .Transaction.Details}}
+    <tr>
+      <td>{{.ProductName}}{{if .PromotionName}}<br><small>Promo {{.PromotionName}}</small>{{end}}</td>
+      <td class="num">{{.Quantity}}</td>
+      <td class="num">{{money .UnitPrice}}</td>
+      <td class="num">{{money (add .PromotionAmount (add .DiscountAmount (add .VoucherAmount .CartDiscountAmount)))}}</td>
+      <td class="num">{{money .Subtotal}}</td>
+    </tr>
+  {{end}}
+  </tbody>
+</table>
+
+<table>
+  <tr><td>Subtotal</td><td class="num">{{money .Transaction.Subtotal}}</td></tr>
+  {{if .Transaction.DiscountAmount}}<tr><td>Diskon</td><td class="num">-{{money .Transaction.DiscountAmount}}</td></tr>{{end}}
+  {{if .Transaction.VoucherAmount}}<tr><td>&nbsp;&nbsp;Voucher {{.Transaction.VoucherCode}}</td><td class="num">-{{money .Transaction.VoucherAmount}}</td></tr>{{end}}
+  {{if .Transaction.ServiceChargeAmount}}<tr><td>Service charge</td><td class="num">{{money .Transaction.ServiceChargeAmount}}</td></tr>{{end}}
+  {{if .Transaction.TaxAmount}}<tr><td>Pajak</td><td class="num">{{money .Transaction.TaxAmount}}</td></tr>{{end}}
+  {{if .Transaction.RoundingAmount}}<tr><td>Pembulatan</td><td class="num">{{money .Transaction.RoundingAmount}}</td></tr>{{end}}
+  <tr class="total"><td>Total</td><td class="num">{{money .PayableAmount}}</td></tr>
+</table>
+
+{{if .Taxes}}
+<table>
+  <thead><tr><th>Pajak</th><th class="num">Tarif</th><th class="num">DPP</th><th class="num">Jumlah</th></tr></thead>
+  <tbody>
+  {{range .Taxes}}
+    <tr>
+      <td>{{.Name}}{{if .Inclusive}} (termasuk){{end}}</td>
+      <td class="num">{{percent .Rate}}</td>
+      <td class="num">{{money .Base}}</td>
+      <td class="num">{{money .Amount}}</td>
+    </tr>
+  {{end}}
+  </tbody>
+</table>
+{{end}}
+
+{{if .Transaction.Payments}}
+<table>
+  <thead><tr><th>Pembayaran</th><th class="num">Jumlah</th></tr></thead>
+  <tbody>
+  {{range .Transaction.Payments}}<tr><td>{{payment .Method}}</td><td class="num">{{money .Amount}}</td></tr>{{end}}
+  <tr><td>Kembali</td><td class="num">{{money .Transaction.ChangeAmount}}</td></tr>
+  </tbody>
+</table>
+{{end}}
+
+{{if .Store.Footer}}<p>{{.Store.Footer}}</p>{{end}}
+</body>
+</html>
+`
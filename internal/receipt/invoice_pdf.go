@@ -0,0 +1,120 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	texttemplate "text/template"
+)
+
+// invoiceCols lebar baris invoice PDF (Courier 10pt di A4 dengan margin 50pt).
+const invoiceCols = 82
+
+// pdfBold prefix baris template PDF yang dicetak tebal.
+const pdfBold = "**"
+
+// Template PDF terpisah dari template HTML karena PDF dibuat tanpa browser engine:
+// hasil template adalah teks monospace, satu baris output = satu baris di PDF.
+// Baris yang diawali "**" dicetak tebal, baris yang lebih panjang dari 82 kolom di-wrap.
+var invoicePDFFuncs = texttemplate.FuncMap{
+	"money":   money,
+	"signed":  signedMoney,
+	"payment": paymentLabel,
+	"date":    invoiceFuncs["date"],
+	"percent": percentLabel,
+	"add":     func(a, b int) int { return a + b },
+	"upper":   strings.ToUpper,
+	// pair label rata kiri & nilai rata kanan selebar halaman
+	"pair": func(l, r string) string {
+		return strings.Join(twoColumns(l, r, invoiceCols), "\n")
+	},
+	"rule": func() string {
+		return strings.Repeat("-", invoiceCols)
+	},
+	// row baris tabel item, nama produk panjang lanjut di baris berikutnya
+	"row": func(name, qty, price, disc, amount string) string {
+		names := wrap(name, 36)
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		out := []string{fmt.Sprintf("%-36s %5s %12s %12s %13s", names[0], qty, price, disc, amount)}
+		return strings.Join(append(out, names[1:]...), "\n")
+	},
+	// bold tandai semua baris s supaya dicetak tebal
+	"bold": func(s string) string {
+		return pdfBold + strings.ReplaceAll(s, "\n", "\n"+pdfBold)
+	},
+}
+
+// ParseInvoicePDFTemplate parse template invoice PDF custom. Template kosong berarti template bawaan.
+func ParseInvoicePDFTemplate(text string) (*texttemplate.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = DefaultInvoicePDFTemplate
+	}
+	return texttemplate.New("invoice_pdf").Funcs(invoicePDFFuncs).Parse(text)
+}
+
+// RenderInvoicePDF render invoice A4 dari template PDF (lihat DefaultInvoicePDFTemplate).
+func RenderInvoicePDF(tmplText string, inv Invoice) ([]byte, error) {
+	tmpl, err := ParseInvoicePDFTemplate(tmplText)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, inv); err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(b.String(), "\r\n", "\n"), "\n")
+
+	var out []line
+	for _, l := range strings.Split(text, "\n") {
+		bold := strings.HasPrefix(l, pdfBold)
+		l = ascii(strings.TrimPrefix(l, pdfBold))
+
+		if len(l) <= invoiceCols {
+			out = append(out, line{text: l, bold: bold})
+			continue
+		}
+		for _, w := range wrap(l, invoiceCols) {
+			out = append(out, line{text: w, bold: bold})
+		}
+	}
+
+	return writePDF(out), nil
+}
+
+// DefaultInvoicePDFTemplate layout invoice PDF bawaan, dipakai kalau store setting belum punya template PDF.
+const DefaultInvoicePDFTemplate = `**{{.Store.Name}}
+{{if .Store.Address}}{{.Store.Address}}
+{{end}}{{if .Store.Phone}}Telp. {{.Store.Phone}}
+{{end}}{{if .Store.NPWP}}NPWP {{.Store.NPWP}}
+{{end}}
+**INVOICE
+{{pair "No. Invoice" .Transaction.InvoiceNumber}}
+{{pair "Tanggal" (date .IssuedAt)}}
+{{if and .Transaction.Status (ne .Transaction.Status "completed")}}{{bold (pair "Status" (upper .Transaction.Status))}}
+{{end}}
+{{bold (row "Produk" "Qty" "Harga" "Diskon" "Jumlah")}}
+{{rule}}
+{{range .Transaction.Details}}{{row .ProductName (print .Quantity) (money .UnitPrice) (money (add .PromotionAmount (add .DiscountAmount (add .VoucherAmount .CartDiscountAmount)))) (money .Subtotal)}}
+{{if .PromotionName}}  Promo {{.PromotionName}}
+{{end}}{{end}}{{rule}}
+{{pair "Subtotal" (money .Transaction.Subtotal)}}
+{{with .Transaction}}{{if gt .DiscountAmount 0}}{{pair "Diskon" (print "-" (money .DiscountAmount))}}
+{{end}}{{if gt .VoucherAmount 0}}{{pair (print "  Voucher " .VoucherCode) (print "-" (money .VoucherAmount))}}
+{{end}}{{if gt .ServiceChargeAmount 0}}{{pair "Service charge" (money .ServiceChargeAmount)}}
+{{end}}{{if gt .TaxAmount 0}}{{pair "Pajak" (money .TaxAmount)}}
+{{end}}{{if ne .RoundingAmount 0}}{{pair "Pembulatan" (signed .RoundingAmount)}}
+{{end}}{{end}}{{bold (pair "TOTAL" (money .PayableAmount))}}
+{{if .Taxes}}
+**Rincian Pajak
+{{range .Taxes}}{{pair (printf "%s - DPP %s" .Label (money .Base)) (money .Amount)}}
+{{end}}{{end}}{{if .Transaction.Payments}}
+**Pembayaran
+{{range .Transaction.Payments}}{{pair (payment .Method) (money .Amount)}}
+{{end}}{{pair "Kembali" (money .Transaction.ChangeAmount)}}
+{{end}}{{if .Store.Footer}}
+{{.Store.Footer}}
+{{end}}`
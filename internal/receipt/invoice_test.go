@@ -0,0 +1,57 @@
+package receipt
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRenderInvoicePDFDefaultGolden layout PDF bawaan (template kosong) terhadap testdata.
+func TestRenderInvoicePDFDefaultGolden(t *testing.T) {
+	got, err := RenderInvoicePDF("", NewInvoice(fixtureStore(), fixtureTransaction(), time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "invoice_default_pdf.golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestRenderInvoicePDFCustomTemplate(t *testing.T) {
+	tmpl := "**{{.Store.Name}}\n{{pair \"Total\" (money .PayableAmount)}}\n"
+
+	got, err := RenderInvoicePDF(tmpl, NewInvoice(fixtureStore(), fixtureTransaction(), time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"/F2 10 Tf\n(Toko Kasir Sejahtera) Tj T*\n",
+		"/F1 10 Tf\n(Total" + strings.Repeat(" ", invoiceCols-len("Total")-len("110.500")) + "110.500) Tj T*\n",
+	} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("pdf does not contain %q", want)
+		}
+	}
+}
+
+func TestParseInvoicePDFTemplateInvalid(t *testing.T) {
+	if _, err := ParseInvoicePDFTemplate("{{.Store.Name"); err == nil {
+		t.Error("expected parse error")
+	}
+}
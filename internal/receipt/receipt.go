@@ -0,0 +1,311 @@
+// Package receipt render struk transaksi untuk printer thermal 58mm / 80mm.
+// Layout dibangun sekali sebagai daftar baris, lalu di-encode sebagai plain text (preview)
+// atau byte ESC/POS (siap kirim ke printer), jadi isi kedua format selalu sama.
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/internal/dto"
+	"strings"
+	"time"
+)
+
+const (
+	FormatText   = "text"
+	FormatESCPOS = "escpos"
+
+	Width58 = 58
+	Width80 = 80
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown receipt format")
+	ErrUnknownWidth  = errors.New("unknown paper width")
+)
+
+// Store identitas toko di header & footer struk.
+type Store struct {
+	Name    string
+	Address string
+	Phone   string
+	NPWP    string
+	Footer  string
+}
+
+type Options struct {
+	Format string
+	// Width lebar kertas dalam mm (58 atau 80).
+	Width int
+	// Location timezone jam transaksi di struk, nil berarti time.Local.
+	Location *time.Location
+}
+
+// Columns jumlah karakter per baris untuk font A standar.
+func Columns(width int) (int, error) {
+	switch width {
+	case Width58:
+		return 32, nil
+	case Width80:
+		return 48, nil
+	default:
+		return 0, ErrUnknownWidth
+	}
+}
+
+func Render(store Store, trx dto.Transaction, opts Options) ([]byte, error) {
+	cols, err := Columns(opts.Width)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := layout(store, trx, cols, opts.Location)
+
+	switch opts.Format {
+	case FormatText:
+		return encodeText(lines, cols), nil
+	case FormatESCPOS:
+		return encodeESCPOS(lines), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+)
+
+type line struct {
+	text  string
+	align align
+	bold  bool
+}
+
+func layout(store Store, trx dto.Transaction, cols int, loc *time.Location) []line {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var out []line
+	center := func(s string, bold bool) {
+		for _, w := range wrap(s, cols) {
+			out = append(out, line{text: w, align: alignCenter, bold: bold})
+		}
+	}
+	left := func(s string) {
+		for _, w := range wrap(s, cols) {
+			out = append(out, line{text: w})
+		}
+	}
+	pair := func(l, r string, bold bool) {
+		for _, w := range twoColumns(l, r, cols) {
+			out = append(out, line{text: w, bold: bold})
+		}
+	}
+	rule := func() {
+		out = append(out, line{text: strings.Repeat("-", cols)})
+	}
+
+	// Header
+	center(store.Name, true)
+	if store.Address != "" {
+		center(store.Address, false)
+	}
+	if store.Phone != "" {
+		center("Telp. "+store.Phone, false)
+	}
+	rule()
+
+	pair("No", trx.InvoiceNumber, false)
+	pair("Tanggal", trx.CreatedAt.In(loc).Format("02-01-2006 15:04"), false)
+	if trx.TerminalID != "" {
+		pair("Terminal", trx.TerminalID, false)
+	}
+	rule()
+
+	// Items
+	for _, d := range trx.Details {
+		left(d.ProductName)
+		pair(fmt.Sprintf("  %d x %s", d.Quantity, money(d.UnitPrice)), money(d.UnitPrice*d.Quantity), false)
+		if d.PromotionAmount > 0 {
+			pair("  Promo "+d.PromotionName, "-"+money(d.PromotionAmount), false)
+		}
+		if d.DiscountAmount > 0 {
+			pair("  Diskon", "-"+money(d.DiscountAmount), false)
+		}
+	}
+	rule()
+
+	// Totals
+	pair("Subtotal", money(trx.Subtotal), false)
+	if trx.DiscountAmount > 0 {
+		pair("Diskon", "-"+money(trx.DiscountAmount), false)
+	}
+	if trx.VoucherAmount > 0 {
+		pair("  Voucher "+trx.VoucherCode, "-"+money(trx.VoucherAmount), false)
+	}
+	if trx.ServiceChargeAmount > 0 {
+		pair("Service", money(trx.ServiceChargeAmount), false)
+	}
+	if trx.TaxAmount > 0 {
+		pair(taxLabel(trx.Details), money(trx.TaxAmount), false)
+	}
+	if trx.RoundingAmount != 0 {
+		pair("Pembulatan", signedMoney(trx.RoundingAmount), false)
+	}
+	pair("TOTAL", money(trx.Total+trx.RoundingAmount), true)
+	rule()
+
+	// Payments
+	for _, p := range trx.Payments {
+		pair(paymentLabel(p.Method), money(p.Amount), false)
+	}
+	pair("Kembali", money(trx.ChangeAmount), false)
+	if trx.PointsEarned > 0 {
+		pair("Poin didapat", fmt.Sprint(trx.PointsEarned), false)
+	}
+
+	if trx.Status != "" && trx.Status != "completed" {
+		rule()
+		center("*** "+strings.ToUpper(trx.Status)+" ***", true)
+	}
+
+	if store.Footer != "" {
+		rule()
+		center(store.Footer, false)
+	}
+
+	return out
+}
+
+// taxLabel "Pajak" atau "Pajak (termasuk)" kalau semua pajak sudah termasuk harga.
+func taxLabel(details []dto.TransactionDetail) string {
+	taxed, inclusive := 0, 0
+	for _, d := range details {
+		if d.TaxAmount <= 0 {
+			continue
+		}
+		taxed++
+		if d.TaxInclusive {
+			inclusive++
+		}
+	}
+
+	if taxed > 0 && inclusive == taxed {
+		return "Pajak (termasuk)"
+	}
+	return "Pajak"
+}
+
+func paymentLabel(method string) string {
+	switch method {
+	case "cash":
+		return "Tunai"
+	case "qris":
+		return "QRIS"
+	case "debit_card":
+		return "Kartu Debit"
+	case "credit_card":
+		return "Kartu Kredit"
+	case "transfer":
+		return "Transfer"
+	case "points":
+		return "Poin"
+	case "credit":
+		return "Kasbon"
+	default:
+		return method
+	}
+}
+
+// money format rupiah dengan pemisah ribuan titik, misal 12500 -> "12.500".
+func money(amount int) string {
+	neg := amount < 0
+	if neg {
+		amount = -amount
+	}
+
+	s := fmt.Sprintf("%d", amount)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(c)
+	}
+
+	if neg {
+		return "-" + b.String()
+	}
+	return b.String()
+}
+
+func signedMoney(amount int) string {
+	if amount > 0 {
+		return "+" + money(amount)
+	}
+	return money(amount)
+}
+
+// twoColumns taruh l di kiri dan r rata kanan. Kalau tidak muat satu baris,
+// l di-wrap dulu dan r ditaruh rata kanan di baris berikutnya.
+func twoColumns(l, r string, cols int) []string {
+	l, r = ascii(l), ascii(r)
+	if len(l)+1+len(r) <= cols {
+		return []string{l + strings.Repeat(" ", cols-len(l)-len(r)) + r}
+	}
+
+	out := wrap(l, cols)
+	for _, w := range wrap(r, cols) {
+		out = append(out, strings.Repeat(" ", cols-len(w))+w)
+	}
+	return out
+}
+
+// wrap pecah s per kata supaya tiap baris <= cols; kata yang lebih panjang dari cols dipotong.
+func wrap(s string, cols int) []string {
+	var out []string
+	cur := ""
+	for _, word := range strings.Fields(ascii(s)) {
+		for len(word) > cols {
+			if cur != "" {
+				out = append(out, cur)
+				cur = ""
+			}
+			out = append(out, word[:cols])
+			word = word[cols:]
+		}
+
+		switch {
+		case cur == "":
+			cur = word
+		case len(cur)+1+len(word) <= cols:
+			cur += " " + word
+		default:
+			out = append(out, cur)
+			cur = word
+		}
+	}
+
+	if cur != "" {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// ascii ganti karakter non-ASCII dengan '?' karena code page printer thermal murah tidak konsisten,
+// sekaligus supaya len() = jumlah kolom.
+func ascii(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if c < 0x20 || c > 0x7e {
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
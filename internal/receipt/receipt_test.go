@@ -0,0 +1,121 @@
+package receipt
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"kasir-api/internal/dto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func fixtureStore() Store {
+	return Store{
+		Name:    "Toko Kasir Sejahtera",
+		Address: "Jl. Merdeka No. 17, Kebayoran Baru, Jakarta Selatan",
+		Phone:   "021-555-0123",
+		NPWP:    "01.234.567.8-901.000",
+		Footer:  "Terima kasih, barang yang sudah dibeli tidak dapat ditukar",
+	}
+}
+
+func fixtureTransaction() dto.Transaction {
+	return dto.Transaction{
+		ID:             42,
+		InvoiceNumber:  "INV/20260115/0042",
+		TerminalID:     "KASIR-01",
+		Subtotal:       103500,
+		DiscountAmount: 3500,
+		TaxAmount:      11000,
+		Total:          111000,
+		RoundingAmount: -500,
+		PaidAmount:     150000,
+		ChangeAmount:   39500,
+		PointsEarned:   11,
+		Status:         "completed",
+		CreatedAt:      time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC),
+		Details: []dto.TransactionDetail{
+			{
+				ProductName: "Kopi Susu Gula Aren Ukuran Jumbo Extra Shot Espresso",
+				UnitPrice:   25000,
+				Quantity:    3,
+				Subtotal:    75000,
+				TaxName:     "PPN",
+				TaxRate:     11,
+				TaxAmount:   8250,
+			},
+			{
+				ProductName:    "Roti Bakar",
+				UnitPrice:      14250,
+				Quantity:       2,
+				DiscountAmount: 3500,
+				Subtotal:       25000,
+				TaxName:        "PPN",
+				TaxRate:        11,
+				TaxAmount:      2750,
+			},
+		},
+		Payments: []dto.TransactionPayment{
+			{Method: "qris", Amount: 50000},
+			{Method: "cash", Amount: 100000},
+		},
+	}
+}
+
+// TestRenderGolden cek output struk per lebar kertas & format terhadap testdata/*.golden.
+// Jalankan `go test ./internal/receipt -update` setelah mengubah layout dengan sengaja.
+func TestRenderGolden(t *testing.T) {
+	for _, format := range []string{FormatText, FormatESCPOS} {
+		for _, width := range []int{Width58, Width80} {
+			name := fmt.Sprintf("%s_%d", format, width)
+			t.Run(name, func(t *testing.T) {
+				got, err := Render(fixtureStore(), fixtureTransaction(), Options{
+					Format:   format,
+					Width:    width,
+					Location: time.UTC,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				path := filepath.Join("testdata", "receipt_"+name+".golden")
+				if *update {
+					if err := os.WriteFile(path, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestRenderInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want error
+	}{
+		{name: "unknown width", opts: Options{Format: FormatText, Width: 72}, want: ErrUnknownWidth},
+		{name: "unknown format", opts: Options{Format: "pdf", Width: Width58}, want: ErrUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(fixtureStore(), fixtureTransaction(), tt.opts); err != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
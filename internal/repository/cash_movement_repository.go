@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type CashMovementRepository interface {
+	Create(ctx context.Context, m entity.CashMovement) (entity.CashMovement, error)
+	FindByShiftID(ctx context.Context, shiftID uint) ([]entity.CashMovement, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type CreditRepository interface {
+	Charge(ctx context.Context, customerID uint, amount int) error
+	Release(ctx context.Context, customerID uint, amount int) (int, error)
+	CreateRepayment(ctx context.Context, r entity.CreditRepayment) (entity.CreditRepayment, error)
+	FindRepaymentsByCustomerID(ctx context.Context, customerID uint, page int, limit int) ([]entity.CreditRepayment, int64, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type CustomerGroupRepository interface {
+	Create(ctx context.Context, g entity.CustomerGroup) (entity.CustomerGroup, error)
+	FindByID(ctx context.Context, id uint) (entity.CustomerGroup, error)
+	FindAll(ctx context.Context) ([]entity.CustomerGroup, error)
+	Update(ctx context.Context, g entity.CustomerGroup) (entity.CustomerGroup, error)
+	Delete(ctx context.Context, id uint) error
+
+	FindPrices(ctx context.Context, groupID uint) ([]entity.GroupPrice, error)
+	CreatePrice(ctx context.Context, p entity.GroupPrice) (entity.GroupPrice, error)
+	UpdatePrice(ctx context.Context, p entity.GroupPrice) (entity.GroupPrice, error)
+	DeletePrice(ctx context.Context, groupID uint, id uint) error
+}
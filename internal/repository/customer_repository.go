@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+)
+
+type CustomerRepository interface {
+	Create(ctx context.Context, c entity.Customer) (entity.Customer, error)
+	FindByID(ctx context.Context, id uint) (entity.Customer, error)
+	FindAll(ctx context.Context, f dto.CustomerFilter) ([]entity.Customer, int64, error)
+	Update(ctx context.Context, c entity.Customer) (entity.Customer, error)
+	Delete(ctx context.Context, id uint) error
+	Stats(ctx context.Context, id uint) (entity.CustomerStats, error)
+}
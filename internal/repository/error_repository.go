@@ -6,4 +6,10 @@ var (
 	ErrNotFound  = errors.New("not found")
 	ErrConflict  = errors.New("conflict")
 	ErrForbidden = errors.New("forbidden")
+
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrInsufficientPoints = errors.New("insufficient points")
+	ErrCreditLimit        = errors.New("credit limit exceeded")
+	ErrOverpayment        = errors.New("amount exceeds balance")
+	ErrVoucherUnavailable = errors.New("voucher unavailable")
 )
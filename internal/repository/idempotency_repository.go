@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, k entity.IdempotencyKey) (bool, error)
+	FindByKey(ctx context.Context, key string) (entity.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key string, trxID uint, body string) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type InvoiceCounterRepository interface {
+	Next(ctx context.Context, scope string, period time.Time) (int, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type LoyaltyRepository interface {
+	Post(ctx context.Context, entry entity.LoyaltyLedger) (entity.LoyaltyLedger, error)
+	FindByCustomerID(ctx context.Context, customerID uint, page int, limit int) ([]entity.LoyaltyLedger, int64, error)
+}
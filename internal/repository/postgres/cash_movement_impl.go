@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type cashMovementRepo struct {
+	db *gorm.DB
+}
+
+func NewCashMovementRepository(db *gorm.DB) *cashMovementRepo {
+	return &cashMovementRepo{db: db}
+}
+
+func (r *cashMovementRepo) Create(ctx context.Context, m entity.CashMovement) (entity.CashMovement, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CashMovementRepository.Create"),
+		zap.Uint("shift_id", m.ShiftID),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.CashMovement{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("cash_movement_id", m.ID))
+
+	return m, nil
+}
+
+func (r *cashMovementRepo) FindByShiftID(ctx context.Context, shiftID uint) ([]entity.CashMovement, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CashMovementRepository.FindByShiftID"),
+		zap.Uint("shift_id", shiftID),
+	)
+
+	log.Info("in")
+
+	var out []entity.CashMovement
+	if err := conn(ctx, r.db).
+		Where("shift_id = ?", shiftID).
+		Order("created_at, id").
+		Find(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)))
+
+	return out, nil
+}
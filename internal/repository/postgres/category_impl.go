@@ -29,7 +29,7 @@ func (r *categoryRepo) Create(ctx context.Context, c entity.Category) (entity.Ca
 	log.Info("in")
 
 	var exist entity.Category
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("name = ?", c.Name).
 		First(&exist).Error
 
@@ -43,7 +43,7 @@ func (r *categoryRepo) Create(ctx context.Context, c entity.Category) (entity.Ca
 		return entity.Category{}, err
 	}
 
-	if err := r.db.WithContext(ctx).Create(&c).Error; err != nil {
+	if err := conn(ctx, r.db).Create(&c).Error; err != nil {
 		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
 		return entity.Category{}, err
 	}
@@ -63,7 +63,7 @@ func (r *categoryRepo) FindByID(ctx context.Context, id uint) (entity.Category,
 	log.Info("in")
 
 	var c entity.Category
-	err := r.db.WithContext(ctx).Take(&c, id).Error
+	err := conn(ctx, r.db).Take(&c, id).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Info("out", zap.String("result", "not_found"))
@@ -89,7 +89,7 @@ func (r *categoryRepo) FindAll(ctx context.Context) ([]entity.Category, error) {
 	log.Info("in")
 
 	var categories []entity.Category
-	if err := r.db.WithContext(ctx).Find(&categories).Error; err != nil {
+	if err := conn(ctx, r.db).Find(&categories).Error; err != nil {
 		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
 		return nil, err
 	}
@@ -109,7 +109,7 @@ func (r *categoryRepo) Update(ctx context.Context, c entity.Category) (entity.Ca
 	log.Info("in")
 
 	var current entity.Category
-	if err := r.db.WithContext(ctx).First(&current, c.ID).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, c.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("out", zap.String("result", "not_found"))
 			return entity.Category{}, repository.ErrNotFound
@@ -135,11 +135,11 @@ func (r *categoryRepo) Update(ctx context.Context, c entity.Category) (entity.Ca
 	}
 
 	// update (autoUpdateTime akan set updated_at otomatis)
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).
 		Model(&entity.Category{}).
 		Where("id = ?", c.ID).
 		Updates(updates).Error; err != nil {
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
+		if isDuplicatedKey(err) {
 			log.Info("out", zap.String("result", "conflict"))
 			return entity.Category{}, repository.ErrConflict
 		}
@@ -148,7 +148,7 @@ func (r *categoryRepo) Update(ctx context.Context, c entity.Category) (entity.Ca
 		return entity.Category{}, err
 	}
 
-	if err := r.db.WithContext(ctx).First(&current, c.ID).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, c.ID).Error; err != nil {
 		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
 		return entity.Category{}, err
 	}
@@ -173,7 +173,7 @@ func (r *categoryRepo) Delete(ctx context.Context, id uint) error {
 	}
 
 	var current entity.Category
-	if err := r.db.WithContext(ctx).First(&current, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("out", zap.String("result", "not_found"))
 			return repository.ErrNotFound
@@ -182,7 +182,12 @@ func (r *categoryRepo) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 
-	if err := r.db.WithContext(ctx).Delete(&entity.Category{}, id).Error; err != nil {
+	if err := conn(ctx, r.db).Delete(&entity.Category{}, id).Error; err != nil {
+		// voucher_category RESTRICT: category masih membatasi voucher
+		if isForeignKeyViolated(err) {
+			log.Info("out", zap.String("result", "conflict_used_by_voucher"))
+			return repository.ErrConflict
+		}
 		log.Error("out", zap.String("result", "delete_failed"), zap.Error(err))
 		return err
 	}
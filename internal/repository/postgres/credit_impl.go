@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type creditRepo struct {
+	db *gorm.DB
+}
+
+func NewCreditRepository(db *gorm.DB) *creditRepo {
+	return &creditRepo{db: db}
+}
+
+// Charge tambah piutang customer hanya kalau saldo setelahnya masih <= credit limit.
+// Conditional update, jadi dua checkout credit paralel tidak bisa sama-sama lolos melewati limit.
+func (r *creditRepo) Charge(ctx context.Context, customerID uint, amount int) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CreditRepository.Charge"),
+		zap.Uint("customer_id", customerID),
+		zap.Int("amount", amount),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Customer{}).
+		Where("id = ? AND credit_balance + ? <= credit_limit", customerID, amount).
+		UpdateColumn("credit_balance", gorm.Expr("credit_balance + ?", amount))
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		err := r.missingOr(ctx, customerID, repository.ErrCreditLimit)
+		log.Info("out", zap.String("result", "not_charged"), zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// Release kurangi piutang karena transaksi credit diretur / di-void / di-refund, paling banyak sebesar
+// saldo piutang customer (yang sudah dilunasi tidak bisa dilepas lagi). Return nominal yang dilepas.
+func (r *creditRepo) Release(ctx context.Context, customerID uint, amount int) (int, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CreditRepository.Release"),
+		zap.Uint("customer_id", customerID),
+		zap.Int("amount", amount),
+	)
+
+	log.Info("in")
+
+	var released []int
+	if err := conn(ctx, r.db).Raw(`
+		WITH c AS (
+			SELECT id, LEAST(?, GREATEST(credit_balance, 0)) AS released
+			FROM customer
+			WHERE id = ?
+			FOR UPDATE
+		)
+		UPDATE customer
+		SET credit_balance = customer.credit_balance - c.released
+		FROM c
+		WHERE customer.id = c.id
+		RETURNING c.released
+	`, amount, customerID).Scan(&released).Error; err != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(err))
+		return 0, err
+	}
+
+	if len(released) == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return 0, repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("released", released[0]))
+
+	return released[0], nil
+}
+
+// CreateRepayment kurangi piutang (tidak boleh melebihi saldo) lalu simpan pelunasannya.
+func (r *creditRepo) CreateRepayment(ctx context.Context, p entity.CreditRepayment) (entity.CreditRepayment, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CreditRepository.CreateRepayment"),
+		zap.Uint("customer_id", p.CustomerID),
+		zap.Int("amount", p.Amount),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Customer{}).
+		Where("id = ? AND credit_balance >= ?", p.CustomerID, p.Amount).
+		UpdateColumn("credit_balance", gorm.Expr("credit_balance - ?", p.Amount))
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.CreditRepayment{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		err := r.missingOr(ctx, p.CustomerID, repository.ErrOverpayment)
+		log.Info("out", zap.String("result", "not_applied"), zap.Error(err))
+		return entity.CreditRepayment{}, err
+	}
+
+	if err := conn(ctx, r.db).Create(&p).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.CreditRepayment{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("repayment_id", p.ID))
+
+	return p, nil
+}
+
+func (r *creditRepo) FindRepaymentsByCustomerID(ctx context.Context, customerID uint, page int, limit int) ([]entity.CreditRepayment, int64, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CreditRepository.FindRepaymentsByCustomerID"),
+		zap.Uint("customer_id", customerID),
+	)
+
+	log.Info("in")
+
+	q := conn(ctx, r.db).Model(&entity.CreditRepayment{}).Where("customer_id = ?", customerID)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	var out []entity.CreditRepayment
+	if err := q.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)), zap.Int64("total", total))
+
+	return out, total, nil
+}
+
+// missingOr bedakan conditional update yang gagal karena customer tidak ada atau karena kondisinya.
+func (r *creditRepo) missingOr(ctx context.Context, customerID uint, err error) error {
+	var count int64
+	if dbErr := conn(ctx, r.db).Model(&entity.Customer{}).Where("id = ?", customerID).Count(&count).Error; dbErr != nil {
+		return dbErr
+	}
+	if count == 0 {
+		return repository.ErrNotFound
+	}
+	return err
+}
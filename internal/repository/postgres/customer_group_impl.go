@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type customerGroupRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomerGroupRepository(db *gorm.DB) *customerGroupRepo {
+	return &customerGroupRepo{db: db}
+}
+
+func (r *customerGroupRepo) Create(ctx context.Context, g entity.CustomerGroup) (entity.CustomerGroup, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.Create"),
+		zap.String("name", g.Name),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&g).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.CustomerGroup{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.CustomerGroup{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("customer_group_id", g.ID))
+
+	return g, nil
+}
+
+func (r *customerGroupRepo) FindByID(ctx context.Context, id uint) (entity.CustomerGroup, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.FindByID"),
+		zap.Uint("customer_group_id", id),
+	)
+
+	log.Info("in")
+
+	var g entity.CustomerGroup
+	if err := conn(ctx, r.db).Take(&g, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.CustomerGroup{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.CustomerGroup{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return g, nil
+}
+
+func (r *customerGroupRepo) FindAll(ctx context.Context) ([]entity.CustomerGroup, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	var groups []entity.CustomerGroup
+	if err := conn(ctx, r.db).Order("name, id").Find(&groups).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(groups)))
+
+	return groups, nil
+}
+
+func (r *customerGroupRepo) Update(ctx context.Context, g entity.CustomerGroup) (entity.CustomerGroup, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.Update"),
+		zap.Uint("customer_group_id", g.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.CustomerGroup{}).
+		Where("id = ?", g.ID).
+		Updates(map[string]interface{}{
+			"name":        g.Name,
+			"description": g.Description,
+		})
+	if res.Error != nil {
+		if isDuplicatedKey(res.Error) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.CustomerGroup{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.CustomerGroup{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.CustomerGroup{}, repository.ErrNotFound
+	}
+
+	var current entity.CustomerGroup
+	if err := conn(ctx, r.db).Take(&current, g.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.CustomerGroup{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+// Delete hapus grup beserta price list-nya. Return ErrForbidden kalau masih ada customer di grup ini.
+func (r *customerGroupRepo) Delete(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.Delete"),
+		zap.Uint("customer_group_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).Delete(&entity.CustomerGroup{}, id)
+	if res.Error != nil {
+		if isForeignKeyViolated(res.Error) {
+			log.Info("out", zap.String("result", "forbidden_has_customers"))
+			return repository.ErrForbidden
+		}
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (r *customerGroupRepo) FindPrices(ctx context.Context, groupID uint) ([]entity.GroupPrice, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.FindPrices"),
+		zap.Uint("customer_group_id", groupID),
+	)
+
+	log.Info("in")
+
+	var prices []entity.GroupPrice
+	if err := conn(ctx, r.db).
+		Where("customer_group_id = ?", groupID).
+		Order("id").
+		Find(&prices).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(prices)))
+
+	return prices, nil
+}
+
+func (r *customerGroupRepo) CreatePrice(ctx context.Context, p entity.GroupPrice) (entity.GroupPrice, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.CreatePrice"),
+		zap.Uint("customer_group_id", p.CustomerGroupID),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&p).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.GroupPrice{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.GroupPrice{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("group_price_id", p.ID))
+
+	return p, nil
+}
+
+// UpdatePrice replace semua field harga, termasuk target product/category.
+func (r *customerGroupRepo) UpdatePrice(ctx context.Context, p entity.GroupPrice) (entity.GroupPrice, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.UpdatePrice"),
+		zap.Uint("customer_group_id", p.CustomerGroupID),
+		zap.Uint("group_price_id", p.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.GroupPrice{}).
+		Where("id = ? AND customer_group_id = ?", p.ID, p.CustomerGroupID).
+		Updates(map[string]interface{}{
+			"product_id":  p.ProductID,
+			"category_id": p.CategoryID,
+			"type":        p.Type,
+			"value":       p.Value,
+		})
+	if res.Error != nil {
+		if isDuplicatedKey(res.Error) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.GroupPrice{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.GroupPrice{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.GroupPrice{}, repository.ErrNotFound
+	}
+
+	var current entity.GroupPrice
+	if err := conn(ctx, r.db).Take(&current, p.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.GroupPrice{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+func (r *customerGroupRepo) DeletePrice(ctx context.Context, groupID uint, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerGroupRepository.DeletePrice"),
+		zap.Uint("customer_group_id", groupID),
+		zap.Uint("group_price_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Where("customer_group_id = ?", groupID).
+		Delete(&entity.GroupPrice{}, id)
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type customerRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) *customerRepo {
+	return &customerRepo{db: db}
+}
+
+func (r *customerRepo) Create(ctx context.Context, c entity.Customer) (entity.Customer, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.Create"),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&c).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.Customer{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.Customer{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("customer_id", c.ID))
+
+	return c, nil
+}
+
+func (r *customerRepo) FindByID(ctx context.Context, id uint) (entity.Customer, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.FindByID"),
+		zap.Uint("customer_id", id),
+	)
+
+	log.Info("in")
+
+	var c entity.Customer
+	if err := conn(ctx, r.db).Take(&c, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Customer{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Customer{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return c, nil
+}
+
+func (r *customerRepo) FindAll(ctx context.Context, f dto.CustomerFilter) ([]entity.Customer, int64, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	q := conn(ctx, r.db).Model(&entity.Customer{})
+
+	switch {
+	case f.Q != "" && f.Phone != "":
+		q = q.Where("name ILIKE ? OR phone LIKE ?", "%"+escapeLike(f.Q)+"%", "%"+escapeLike(f.Phone)+"%")
+	case f.Q != "":
+		q = q.Where("name ILIKE ?", "%"+escapeLike(f.Q)+"%")
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	var customers []entity.Customer
+	if err := q.
+		Order("name, id").
+		Limit(f.Limit).
+		Offset((f.Page - 1) * f.Limit).
+		Find(&customers).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	log.Info("out", zap.Int("count", len(customers)), zap.Int64("total", total))
+
+	return customers, total, nil
+}
+
+func (r *customerRepo) Update(ctx context.Context, c entity.Customer) (entity.Customer, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.Update"),
+		zap.Uint("customer_id", c.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Customer{}).
+		Where("id = ?", c.ID).
+		Updates(map[string]interface{}{
+			"name":              c.Name,
+			"phone":             c.Phone,
+			"email":             c.Email,
+			"address":           c.Address,
+			"note":              c.Note,
+			"credit_limit":      c.CreditLimit,
+			"customer_group_id": c.CustomerGroupID,
+		})
+	if res.Error != nil {
+		if isDuplicatedKey(res.Error) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.Customer{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Customer{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.Customer{}, repository.ErrNotFound
+	}
+
+	var current entity.Customer
+	if err := conn(ctx, r.db).Take(&current, c.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Customer{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+// Delete hapus customer. Return ErrForbidden kalau customer sudah punya transaksi.
+func (r *customerRepo) Delete(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.Delete"),
+		zap.Uint("customer_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).Delete(&entity.Customer{}, id)
+	if res.Error != nil {
+		if isForeignKeyViolated(res.Error) {
+			log.Info("out", zap.String("result", "forbidden_has_transactions"))
+			return repository.ErrForbidden
+		}
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// Stats lifetime value = total yang dibayar di transaksi completed dikurangi refund retur parsial.
+func (r *customerRepo) Stats(ctx context.Context, id uint) (entity.CustomerStats, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "CustomerRepository.Stats"),
+		zap.Uint("customer_id", id),
+	)
+
+	log.Info("in")
+
+	var stats entity.CustomerStats
+	if err := conn(ctx, r.db).Raw(`
+		SELECT
+			COUNT(*) AS transaction_count,
+			COALESCE(SUM(t.total_amount + t.rounding_amount), 0) - COALESCE((
+				SELECT SUM(tr.refund_amount + tr.points_amount + tr.credit_amount)
+				FROM transaction_return tr
+				JOIN transaction tt ON tt.id = tr.transaction_id
+				WHERE tt.customer_id = ? AND tt.status = ?
+			), 0) AS lifetime_value,
+			MIN(t.created_at) AS first_purchase_at,
+			MAX(t.created_at) AS last_purchase_at
+		FROM transaction t
+		WHERE t.customer_id = ? AND t.status = ?
+	`, id, entity.TrxStatusCompleted, id, entity.TrxStatusCompleted).Scan(&stats).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.CustomerStats{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return stats, nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepo struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *idempotencyRepo {
+	return &idempotencyRepo{db: db}
+}
+
+// Claim insert key baru. Return false kalau key sudah dipakai.
+// Di dalam transaction, request paralel dengan key sama akan menunggu sampai yang pertama commit/rollback.
+func (r *idempotencyRepo) Claim(ctx context.Context, k entity.IdempotencyKey) (bool, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "IdempotencyRepository.Claim"),
+		zap.String("key", k.Key),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&k)
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(res.Error))
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "already_exists"))
+		return false, nil
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return true, nil
+}
+
+func (r *idempotencyRepo) FindByKey(ctx context.Context, key string) (entity.IdempotencyKey, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "IdempotencyRepository.FindByKey"),
+		zap.String("key", key),
+	)
+
+	log.Info("in")
+
+	var k entity.IdempotencyKey
+	if err := conn(ctx, r.db).Where("key = ?", key).Take(&k).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.IdempotencyKey{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.IdempotencyKey{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return k, nil
+}
+
+func (r *idempotencyRepo) SaveResponse(ctx context.Context, key string, trxID uint, body string) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "IdempotencyRepository.SaveResponse"),
+		zap.String("key", key),
+		zap.Uint("transaction_id", trxID),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).
+		Model(&entity.IdempotencyKey{}).
+		Where("key = ?", key).
+		Updates(map[string]interface{}{
+			"transaction_id": trxID,
+			"response_body":  body,
+		}).Error; err != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
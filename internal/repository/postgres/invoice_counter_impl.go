@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type invoiceCounterRepo struct {
+	db *gorm.DB
+}
+
+func NewInvoiceCounterRepository(db *gorm.DB) *invoiceCounterRepo {
+	return &invoiceCounterRepo{db: db}
+}
+
+// Next naikkan counter (scope, period) dan return nilai barunya.
+// Wajib dipanggil di dalam transaction checkout: row counter ter-lock sampai commit, jadi checkout
+// paralel di scope yang sama antri, dan kalau checkout rollback nomornya ikut batal (tidak bolong).
+func (r *invoiceCounterRepo) Next(ctx context.Context, scope string, period time.Time) (int, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "InvoiceCounterRepository.Next"),
+		zap.String("scope", scope),
+	)
+
+	log.Info("in")
+
+	var next int
+	if err := conn(ctx, r.db).Raw(`
+		INSERT INTO invoice_counter (scope, period, last_value)
+		VALUES (?, ?::date, 1)
+		ON CONFLICT (scope, period) DO UPDATE SET last_value = invoice_counter.last_value + 1
+		RETURNING last_value
+	`, scope, period.Format("2006-01-02")).Scan(&next).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return 0, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("value", next))
+
+	return next, nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type loyaltyRepo struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) *loyaltyRepo {
+	return &loyaltyRepo{db: db}
+}
+
+// Post ubah saldo poin customer lalu catat mutasinya di ledger, harus dipanggil di dalam TxManager.
+// Redeem pakai conditional update (saldo harus cukup) supaya dua checkout paralel tidak bisa
+// memakai poin yang sama; earn & reversal selalu diterapkan walaupun saldo jadi minus.
+func (r *loyaltyRepo) Post(ctx context.Context, entry entity.LoyaltyLedger) (entity.LoyaltyLedger, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "LoyaltyRepository.Post"),
+		zap.Uint("customer_id", entry.CustomerID),
+		zap.String("type", entry.Type),
+		zap.Int("points", entry.Points),
+	)
+
+	log.Info("in")
+
+	query := "UPDATE customer SET points_balance = points_balance + ? WHERE id = ?"
+	args := []interface{}{entry.Points, entry.CustomerID}
+	if entry.Type == entity.LoyaltyRedeem {
+		query += " AND points_balance + ? >= 0"
+		args = append(args, entry.Points)
+	}
+
+	var balances []int
+	if err := conn(ctx, r.db).Raw(query+" RETURNING points_balance", args...).Scan(&balances).Error; err != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(err))
+		return entity.LoyaltyLedger{}, err
+	}
+
+	if len(balances) == 0 {
+		var count int64
+		if err := conn(ctx, r.db).Model(&entity.Customer{}).Where("id = ?", entry.CustomerID).Count(&count).Error; err != nil {
+			log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+			return entity.LoyaltyLedger{}, err
+		}
+		if count == 0 {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.LoyaltyLedger{}, repository.ErrNotFound
+		}
+		log.Info("out", zap.String("result", "insufficient_points"))
+		return entity.LoyaltyLedger{}, repository.ErrInsufficientPoints
+	}
+
+	entry.BalanceAfter = balances[0]
+	if err := conn(ctx, r.db).Create(&entry).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.LoyaltyLedger{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("balance", entry.BalanceAfter))
+
+	return entry, nil
+}
+
+func (r *loyaltyRepo) FindByCustomerID(ctx context.Context, customerID uint, page int, limit int) ([]entity.LoyaltyLedger, int64, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "LoyaltyRepository.FindByCustomerID"),
+		zap.Uint("customer_id", customerID),
+	)
+
+	log.Info("in")
+
+	q := conn(ctx, r.db).Model(&entity.LoyaltyLedger{}).Where("customer_id = ?", customerID)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	var out []entity.LoyaltyLedger
+	if err := q.
+		Order("id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)), zap.Int64("total", total))
+
+	return out, total, nil
+}
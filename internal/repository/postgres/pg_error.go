@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Kode error postgres yang dipetakan repository ke error domain.
+// Dicek lokal di sini (bukan lewat TranslateError global gorm) supaya error lain tetap diteruskan apa adanya.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+func isDuplicatedKey(err error) bool {
+	return isPgError(err, pgUniqueViolation)
+}
+
+func isForeignKeyViolated(err error) bool {
+	return isPgError(err, pgForeignKeyViolation)
+}
@@ -7,6 +7,8 @@ import (
 	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
 	"kasir-api/internal/repository"
+	"sort"
+	"strings"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -33,7 +35,7 @@ func (r *productRepo) Create(ctx context.Context, p entity.Product) (entity.Prod
 		p.CategoryID = 1
 	}
 
-	if err := r.db.WithContext(ctx).Create(&p).Error; err != nil {
+	if err := conn(ctx, r.db).Create(&p).Error; err != nil {
 		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
 		return entity.Product{}, err
 	}
@@ -53,7 +55,7 @@ func (r *productRepo) FindByID(ctx context.Context, id uint) (entity.Product, er
 	log.Info("in")
 
 	var p entity.Product
-	if err := r.db.WithContext(ctx).First(&p, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&p, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("out", zap.String("result", "not_found"))
 			return entity.Product{}, repository.ErrNotFound
@@ -76,7 +78,7 @@ func (r *productRepo) FindAll(ctx context.Context) ([]entity.Product, error) {
 	log.Info("in")
 
 	var products []entity.Product
-	if err := r.db.WithContext(ctx).Find(&products).Error; err != nil {
+	if err := conn(ctx, r.db).Find(&products).Error; err != nil {
 		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
 		return nil, err
 	}
@@ -97,7 +99,7 @@ func (r *productRepo) Update(ctx context.Context, p entity.Product) (entity.Prod
 
 	// ensure exists
 	var current entity.Product
-	if err := r.db.WithContext(ctx).First(&current, p.ID).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, p.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("out", zap.String("result", "not_found"))
 			return entity.Product{}, repository.ErrNotFound
@@ -133,7 +135,7 @@ func (r *productRepo) Update(ctx context.Context, p entity.Product) (entity.Prod
 		return current, nil
 	}
 
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).
 		Model(&entity.Product{}).
 		Where("id = ?", p.ID).
 		Updates(updates).Error; err != nil {
@@ -141,7 +143,7 @@ func (r *productRepo) Update(ctx context.Context, p entity.Product) (entity.Prod
 		return entity.Product{}, err
 	}
 
-	if err := r.db.WithContext(ctx).First(&current, p.ID).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, p.ID).Error; err != nil {
 		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
 		return entity.Product{}, err
 	}
@@ -162,7 +164,7 @@ func (r *productRepo) Delete(ctx context.Context, id uint) error {
 
 	// ensure exists
 	var current entity.Product
-	if err := r.db.WithContext(ctx).First(&current, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&current, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("out", zap.String("result", "not_found"))
 			return repository.ErrNotFound
@@ -171,7 +173,7 @@ func (r *productRepo) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 
-	if err := r.db.WithContext(ctx).Delete(&entity.Product{}, id).Error; err != nil {
+	if err := conn(ctx, r.db).Delete(&entity.Product{}, id).Error; err != nil {
 		log.Error("out", zap.String("result", "delete_failed"), zap.Error(err))
 		return err
 	}
@@ -191,7 +193,7 @@ func (r *productRepo) FindDetailByID(ctx context.Context, id uint) (dto.ProductD
 	log.Info("in")
 
 	var out dto.ProductDetailResponse
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Table("product p").
 		Select(`
 			p.id,
@@ -222,3 +224,207 @@ func (r *productRepo) FindDetailByID(ctx context.Context, id uint) (dto.ProductD
 
 	return out, nil
 }
+
+func (r *productRepo) IncreaseStock(ctx context.Context, id uint, qty int) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ProductRepository.IncreaseStock"),
+		zap.Uint("product_id", id),
+		zap.Int("quantity", qty),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Product{}).
+		Where("id = ?", id).
+		Update("stock", gorm.Expr("stock + ?", qty))
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (r *productRepo) FindDetailByIDs(ctx context.Context, ids []uint) ([]dto.ProductDetailResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ProductRepository.FindDetailByIDs"),
+		zap.Int("product_count", len(ids)),
+	)
+
+	log.Info("in")
+
+	out := []dto.ProductDetailResponse{}
+	if len(ids) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return out, nil
+	}
+
+	if err := conn(ctx, r.db).
+		Table("product p").
+		Select(`
+			p.id,
+			p.category_id,
+			c.name AS category_name,
+			p.name,
+			p.price,
+			p.stock,
+			p.created_at,
+			p.updated_at
+		`).
+		Joins("JOIN category c ON c.id = p.category_id").
+		Where("p.id IN ?", ids).
+		Scan(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)))
+
+	return out, nil
+}
+
+// DecreaseStockBatch kurangi stock beberapa product sekaligus dengan jumlah query tetap.
+// Decrement bersyarat (stock >= qty) di database, jadi dua checkout paralel tidak bisa menjual unit terakhir yang sama.
+// Row di-lock urut id dulu supaya checkout paralel dengan produk yang sama tidak deadlock.
+func (r *productRepo) DecreaseStockBatch(ctx context.Context, qtyByID map[uint]int) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ProductRepository.DecreaseStockBatch"),
+		zap.Int("product_count", len(qtyByID)),
+	)
+
+	log.Info("in")
+
+	if len(qtyByID) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return nil
+	}
+
+	ids := make([]uint, 0, len(qtyByID))
+	for id := range qtyByID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var locked []uint
+	if err := conn(ctx, r.db).
+		Raw("SELECT id FROM product WHERE id IN ? ORDER BY id FOR UPDATE", ids).
+		Scan(&locked).Error; err != nil {
+		log.Error("out", zap.String("result", "lock_failed"), zap.Error(err))
+		return err
+	}
+
+	if len(locked) != len(ids) {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	values := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for _, id := range ids {
+		values = append(values, "(?::int, ?::int)")
+		args = append(args, id, qtyByID[id])
+	}
+
+	res := conn(ctx, r.db).Exec(`
+		UPDATE product AS p
+		SET stock = p.stock - v.qty, updated_at = NOW()
+		FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, qty)
+		WHERE p.id = v.id AND p.stock >= v.qty
+	`, args...)
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	// ada product yang stock-nya kurang -> caller rollback seluruh transaction
+	if res.RowsAffected != int64(len(ids)) {
+		log.Info("out", zap.String("result", "insufficient_stock"))
+		return repository.ErrInsufficientStock
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// FindPriceTiers tier harga grosir beberapa product, urut product lalu min_quantity.
+func (r *productRepo) FindPriceTiers(ctx context.Context, productIDs []uint) ([]entity.ProductPriceTier, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ProductRepository.FindPriceTiers"),
+		zap.Int("product_count", len(productIDs)),
+	)
+
+	log.Info("in")
+
+	tiers := []entity.ProductPriceTier{}
+	if len(productIDs) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return tiers, nil
+	}
+
+	if err := conn(ctx, r.db).
+		Where("product_id IN ?", productIDs).
+		Order("product_id, min_quantity").
+		Find(&tiers).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(tiers)))
+
+	return tiers, nil
+}
+
+// ReplacePriceTiers ganti semua tier product dengan tiers, harus dipanggil di dalam TxManager.
+func (r *productRepo) ReplacePriceTiers(ctx context.Context, productID uint, tiers []entity.ProductPriceTier) ([]entity.ProductPriceTier, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ProductRepository.ReplacePriceTiers"),
+		zap.Uint("product_id", productID),
+		zap.Int("tier_count", len(tiers)),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).
+		Where("product_id = ?", productID).
+		Delete(&entity.ProductPriceTier{}).Error; err != nil {
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	if len(tiers) == 0 {
+		log.Info("out", zap.String("result", "cleared"))
+		return []entity.ProductPriceTier{}, nil
+	}
+
+	for i := range tiers {
+		tiers[i].ID = 0
+		tiers[i].ProductID = productID
+	}
+
+	if err := conn(ctx, r.db).Create(&tiers).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return nil, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return tiers, nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type promotionRepo struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) *promotionRepo {
+	return &promotionRepo{db: db}
+}
+
+func (r *promotionRepo) Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.Create"),
+		zap.String("name", p.Name),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&p).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.Promotion{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("promotion_id", p.ID))
+
+	return p, nil
+}
+
+func (r *promotionRepo) FindByID(ctx context.Context, id uint) (entity.Promotion, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.FindByID"),
+		zap.Uint("promotion_id", id),
+	)
+
+	log.Info("in")
+
+	var p entity.Promotion
+	if err := conn(ctx, r.db).Take(&p, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Promotion{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Promotion{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return p, nil
+}
+
+func (r *promotionRepo) FindAll(ctx context.Context) ([]entity.Promotion, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	var promos []entity.Promotion
+	if err := conn(ctx, r.db).Order("priority DESC, id").Find(&promos).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(promos)))
+
+	return promos, nil
+}
+
+// Update replace semua field promo.
+func (r *promotionRepo) Update(ctx context.Context, p entity.Promotion) (entity.Promotion, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.Update"),
+		zap.Uint("promotion_id", p.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Promotion{}).
+		Where("id = ?", p.ID).
+		Updates(map[string]interface{}{
+			"name":         p.Name,
+			"type":         p.Type,
+			"product_id":   p.ProductID,
+			"category_id":  p.CategoryID,
+			"buy_quantity": p.BuyQuantity,
+			"get_quantity": p.GetQuantity,
+			"bundle_price": p.BundlePrice,
+			"percent":      p.Percent,
+			"starts_at":    p.StartsAt,
+			"ends_at":      p.EndsAt,
+			"start_time":   p.StartTime,
+			"end_time":     p.EndTime,
+			"priority":     p.Priority,
+			"stackable":    p.Stackable,
+			"active":       p.Active,
+		})
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Promotion{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.Promotion{}, repository.ErrNotFound
+	}
+
+	var current entity.Promotion
+	if err := conn(ctx, r.db).Take(&current, p.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Promotion{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+func (r *promotionRepo) Delete(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.Delete"),
+		zap.Uint("promotion_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).Delete(&entity.Promotion{}, id)
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// FindActive promo aktif yang periodenya mencakup at, urut priority tertinggi dulu.
+// Jam berlaku harian dicek di service karena tergantung timezone toko.
+func (r *promotionRepo) FindActive(ctx context.Context, at time.Time) ([]entity.Promotion, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "PromotionRepository.FindActive"),
+	)
+
+	log.Info("in")
+
+	var promos []entity.Promotion
+	if err := conn(ctx, r.db).
+		Where("active").
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at > ?", at).
+		Order("priority DESC, id").
+		Find(&promos).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(promos)))
+
+	return promos, nil
+}
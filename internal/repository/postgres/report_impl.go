@@ -29,39 +29,116 @@ func (r *reportRepo) GetReport(ctx context.Context, startDate string, endDate st
 	ed := nullIfEmpty(endDate)
 
 	var result entity.Report
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).
 		Table("transaction").
 		Select(`
+			COALESCE(SUM(subtotal_amount), 0) AS gross_revenue, 
+			COALESCE(SUM(discount_amount), 0) AS total_discount, 
 			COALESCE(SUM(total_amount), 0) AS total_revenue, 
+			COALESCE(SUM(change_amount), 0) AS total_change, 
+			COALESCE(SUM(rounding_amount), 0) AS total_rounding, 
+			COALESCE(SUM(service_charge_amount), 0) AS total_service_charge, 
+			COALESCE(SUM(tax_amount), 0) AS total_tax, 
 			COUNT(id) AS total_transaction
 		`).
+		Where(`
+			created_at >= COALESCE(?::date, CURRENT_DATE) AND 
+			created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			status = ?
+		`, sd, ed, entity.TrxStatusCompleted).
+		Scan(&result).
+		Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return result, err
+	}
+
+	// pajak unit yang sudah diretur tidak ikut dihitung, prorata per baris sama seperti refund retur
+	var returnedTax int
+	if err := conn(ctx, r.db).
+		Table("transaction_detail td").
+		Select("COALESCE(SUM(td.tax_amount * td.returned_quantity / td.quantity), 0)").
+		Joins(`JOIN "transaction" t ON td.transaction_id = t.id`).
+		Where(`
+			t.created_at >= COALESCE(?::date, CURRENT_DATE) AND 
+			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			t.status = ? AND
+			td.returned_quantity > 0
+		`, sd, ed, entity.TrxStatusCompleted).
+		Scan(&returnedTax).
+		Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return result, err
+	}
+
+	result.TotalTax -= returnedTax
+
+	// retur dihitung di periode retur terjadi, hanya untuk transaction yang masih completed.
+	// Nilai retur = uang yang direfund + bagian poin yang dikembalikan sebagai poin + piutang yang dilepas
+	if err := conn(ctx, r.db).
+		Table("transaction_return tr").
+		Select("COALESCE(SUM(tr.refund_amount + tr.points_amount + tr.credit_amount), 0)").
+		Joins(`JOIN "transaction" t ON tr.transaction_id = t.id`).
+		Where(`
+			tr.created_at >= COALESCE(?::date, CURRENT_DATE) AND 
+			tr.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			t.status = ?
+		`, sd, ed, entity.TrxStatusCompleted).
+		Scan(&result.TotalReturn).
+		Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return result, err
+	}
+
+	// cash in/out laci di luar penjualan, dihitung di periode movement terjadi
+	var cash struct {
+		TotalCashIn  int `gorm:"column:total_cash_in"`
+		TotalCashOut int `gorm:"column:total_cash_out"`
+	}
+	if err := conn(ctx, r.db).
+		Table("cash_movement").
+		Select(`
+			COALESCE(SUM(amount) FILTER (WHERE direction = ?), 0) AS total_cash_in, 
+			COALESCE(SUM(amount) FILTER (WHERE direction = ?), 0) AS total_cash_out
+		`, entity.CashDirectionIn, entity.CashDirectionOut).
 		Where(`
 			created_at >= COALESCE(?::date, CURRENT_DATE) AND 
 			created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day'
 		`, sd, ed).
-		Scan(&result).
+		Scan(&cash).
 		Error; err != nil {
 		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
 		return result, err
 	}
 
+	result.TotalCashIn = cash.TotalCashIn
+	result.TotalCashOut = cash.TotalCashOut
+
+	// qty & subtotal dikurangi item yang sudah diretur, supaya sama dengan revenue yang di-net retur
 	var bestProduct []entity.BestProduct
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).
 		Table("transaction_detail td").
 		Select(`
-			p.name AS name, 
-			SUM(td.quantity) AS quantity, 
-			SUM(td.quantity * p.price) AS subtotal
+			td.product_name AS name, 
+			SUM(td.quantity - td.returned_quantity) AS quantity, 
+			SUM(td.subtotal - COALESCE(rd.refund_amount, 0)) AS subtotal
 		`).
 		Joins(`
-			JOIN product p ON td.product_id = p.id 
 			JOIN "transaction" t ON td.transaction_id = t.id 
 		`).
+		Joins(`
+			LEFT JOIN (
+				SELECT transaction_detail_id, SUM(refund_amount) AS refund_amount
+				FROM transaction_return_detail
+				GROUP BY transaction_detail_id
+			) rd ON rd.transaction_detail_id = td.id
+		`).
 		Where(`
 			t.created_at >= COALESCE(?::date, CURRENT_DATE) AND 
-			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day'
-		`, sd, ed).
-		Group("p.id, p.name").
+			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			t.status = ?
+		`, sd, ed, entity.TrxStatusCompleted).
+		Group("td.product_id, td.product_name").
+		Having("SUM(td.quantity - td.returned_quantity) > 0").
 		Order("quantity DESC").
 		Scan(&bestProduct).
 		Error; err != nil {
@@ -71,11 +148,148 @@ func (r *reportRepo) GetReport(ctx context.Context, startDate string, endDate st
 
 	result.BestProduct = bestProduct
 
+	var payments []entity.PaymentSummary
+	if err := conn(ctx, r.db).
+		Table("transaction_payment tp").
+		Select(`
+			tp.method AS method, 
+			COUNT(DISTINCT tp.transaction_id) AS transaction_count, 
+			SUM(tp.amount) AS amount
+		`).
+		Joins(`JOIN "transaction" t ON tp.transaction_id = t.id`).
+		Where(`
+			t.created_at >= COALESCE(?::date, CURRENT_DATE) AND 
+			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			t.status = ?
+		`, sd, ed, entity.TrxStatusCompleted).
+		Group("tp.method").
+		Order("amount DESC").
+		Scan(&payments).
+		Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return result, err
+	}
+
+	result.Payments = payments
+
+	// dasar pajak & pajak di-net bagian unit yang diretur, sama dengan total_tax
+	var taxes []entity.TaxSummary
+	if err := conn(ctx, r.db).
+		Table("transaction_detail td").
+		Select(`
+			td.tax_name AS name, 
+			td.tax_rate AS rate, 
+			td.tax_inclusive AS inclusive, 
+			SUM(td.tax_base_amount - td.tax_base_amount * td.returned_quantity / td.quantity) AS tax_base, 
+			SUM(td.tax_amount - td.tax_amount * td.returned_quantity / td.quantity) AS tax_amount
+		`).
+		Joins(`JOIN "transaction" t ON td.transaction_id = t.id`).
+		Where(`
+			t.created_at >= COALESCE(?::date, CURRENT_DATE) AND 
+			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' AND
+			t.status = ? AND
+			td.tax_name <> ''
+		`, sd, ed, entity.TrxStatusCompleted).
+		Group("td.tax_name, td.tax_rate, td.tax_inclusive").
+		Order("tax_amount DESC").
+		Scan(&taxes).
+		Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return result, err
+	}
+
+	result.Taxes = taxes
+
 	log.Info("out", zap.String("result", "ok"))
 
 	return result, nil
 }
 
+// FindCreditSales sisa bagian credit tiap transaksi sampai tanggal asOf, urut dari yang terlama per customer
+// (urutan alokasi pelunasan FIFO). customerID 0 berarti semua customer.
+// Piutang yang dilepas retur / void dikurangkan; bagian yang tidak bisa dilepas karena sudah dilunasi
+// (lalu dikembalikan sebagai uang) tetap dihitung supaya pasangan pelunasannya tidak jadi lebih bayar.
+func (r *reportRepo) FindCreditSales(ctx context.Context, customerID uint, asOf string) ([]entity.CreditSale, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ReportRepository.FindCreditSales"),
+	)
+
+	log.Info("in")
+
+	ad := nullIfEmpty(asOf)
+	q := conn(ctx, r.db).
+		Table("transaction_payment tp").
+		Select(`
+			t.customer_id AS customer_id,
+			c.name AS customer_name,
+			t.id AS transaction_id,
+			t.invoice_number AS invoice_number,
+			t.created_at AS created_at,
+			SUM(tp.amount) - MAX(COALESCE(rt.credit_amount, 0)) - MAX(
+				CASE WHEN COALESCE(t.status_updated_at, t.created_at) < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day' THEN t.credit_released ELSE 0 END
+			) AS amount
+		`, ad).
+		Joins(`JOIN "transaction" t ON tp.transaction_id = t.id`).
+		Joins(`JOIN customer c ON c.id = t.customer_id`).
+		Joins(`
+			LEFT JOIN (
+				SELECT transaction_id, SUM(credit_amount) AS credit_amount
+				FROM transaction_return
+				WHERE created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day'
+				GROUP BY transaction_id
+			) rt ON rt.transaction_id = t.id
+		`, ad).
+		Where(`
+			tp.method = ? AND
+			t.created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day'
+		`, entity.PaymentMethodCredit, ad)
+	if customerID != 0 {
+		q = q.Where("t.customer_id = ?", customerID)
+	}
+
+	var out []entity.CreditSale
+	if err := q.
+		Group("t.customer_id, c.name, t.id, t.invoice_number, t.created_at").
+		Order("t.customer_id, t.created_at, t.id").
+		Scan(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)))
+
+	return out, nil
+}
+
+// SumCreditRepayments total pelunasan per customer sampai tanggal asOf.
+func (r *reportRepo) SumCreditRepayments(ctx context.Context, customerID uint, asOf string) ([]entity.CreditRepaid, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ReportRepository.SumCreditRepayments"),
+	)
+
+	log.Info("in")
+
+	q := conn(ctx, r.db).
+		Table("credit_repayment").
+		Select("customer_id, SUM(amount) AS amount").
+		Where("created_at < COALESCE(?::date, CURRENT_DATE) + INTERVAL '1 day'", nullIfEmpty(asOf))
+	if customerID != 0 {
+		q = q.Where("customer_id = ?", customerID)
+	}
+
+	var out []entity.CreditRepaid
+	if err := q.Group("customer_id").Scan(&out).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(out)))
+
+	return out, nil
+}
+
 func nullIfEmpty(s string) any {
 	if s == "" {
 		return nil
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type shiftRepo struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) *shiftRepo {
+	return &shiftRepo{db: db}
+}
+
+// Create buka shift baru. Return ErrConflict kalau terminal masih punya shift open.
+func (r *shiftRepo) Create(ctx context.Context, s entity.Shift) (entity.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.Create"),
+		zap.String("terminal_id", s.TerminalID),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&s).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.Shift{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("shift_id", s.ID))
+
+	return s, nil
+}
+
+func (r *shiftRepo) FindByID(ctx context.Context, id uint) (entity.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.FindByID"),
+		zap.Uint("shift_id", id),
+	)
+
+	log.Info("in")
+
+	var s entity.Shift
+	if err := conn(ctx, r.db).Take(&s, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Shift{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return s, nil
+}
+
+func (r *shiftRepo) LockByID(ctx context.Context, id uint) (entity.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.LockByID"),
+		zap.Uint("shift_id", id),
+	)
+
+	log.Info("in")
+
+	var s entity.Shift
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&s, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Shift{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return s, nil
+}
+
+// FindOpenByTerminal ambil shift open di terminal dengan FOR SHARE: checkout/refund yang sedang
+// berjalan menahan tutup shift sampai commit, jadi hitungan expected cash tidak ketinggalan transaksi.
+func (r *shiftRepo) FindOpenByTerminal(ctx context.Context, terminalID string) (entity.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.FindOpenByTerminal"),
+		zap.String("terminal_id", terminalID),
+	)
+
+	log.Info("in")
+
+	var s entity.Shift
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("terminal_id = ? AND status = ?", terminalID, entity.ShiftStatusOpen).
+		Take(&s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Shift{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("shift_id", s.ID))
+
+	return s, nil
+}
+
+// Close tutup shift yang masih open (conditional). Return ErrConflict kalau sudah closed.
+func (r *shiftRepo) Close(ctx context.Context, s entity.Shift) (entity.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.Close"),
+		zap.Uint("shift_id", s.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Shift{}).
+		Where("id = ? AND status = ?", s.ID, entity.ShiftStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          entity.ShiftStatusClosed,
+			"expected_cash":   s.ExpectedCash,
+			"counted_cash":    s.CountedCash,
+			"cash_difference": s.CashDifference,
+			"closing_note":    s.ClosingNote,
+			"closed_at":       time.Now(),
+		})
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Shift{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "conflict"))
+		return entity.Shift{}, repository.ErrConflict
+	}
+
+	var current entity.Shift
+	if err := conn(ctx, r.db).Take(&current, s.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+func (r *shiftRepo) Summary(ctx context.Context, id uint) (entity.ShiftSummary, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "ShiftRepository.Summary"),
+		zap.Uint("shift_id", id),
+	)
+
+	log.Info("in")
+
+	var sum entity.ShiftSummary
+
+	// Semua transaksi di shift dihitung (termasuk yang kemudian di-void):
+	// uangnya tetap masuk laci, pengembaliannya dicatat terpisah sebagai refund.
+	if err := conn(ctx, r.db).Raw(`
+		SELECT
+			COUNT(*) AS transaction_count,
+			COALESCE(SUM(t.total_amount + t.rounding_amount), 0) AS sales_amount,
+			COALESCE(SUM(t.change_amount), 0) AS change_given,
+			(
+				SELECT COALESCE(SUM(tp.amount), 0)
+				FROM transaction_payment tp
+				JOIN transaction tc ON tc.id = tp.transaction_id
+				WHERE tc.shift_id = ? AND tp.method = ?
+			) AS cash_received,
+			(
+				SELECT COALESCE(SUM(cm.amount), 0) FROM cash_movement cm
+				WHERE cm.shift_id = ? AND cm.direction = ?
+			) AS cash_in,
+			(
+				SELECT COALESCE(SUM(cm.amount), 0) FROM cash_movement cm
+				WHERE cm.shift_id = ? AND cm.direction = ?
+			) AS cash_out,
+			(
+				SELECT COALESCE(SUM(cr.amount), 0) FROM credit_repayment cr
+				WHERE cr.shift_id = ? AND cr.method = ?
+			) AS credit_repayment_cash
+		FROM transaction t
+		WHERE t.shift_id = ?
+	`, id, entity.PaymentMethodCash, id, entity.CashDirectionIn, id, entity.CashDirectionOut, id, entity.PaymentMethodCash, id).Scan(&sum).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.ShiftSummary{}, err
+	}
+
+	if err := conn(ctx, r.db).Raw(`
+		SELECT
+			tp.method,
+			COUNT(DISTINCT tp.transaction_id) AS transaction_count,
+			COALESCE(SUM(tp.amount), 0) AS amount
+		FROM transaction_payment tp
+		JOIN transaction t ON t.id = tp.transaction_id
+		WHERE t.shift_id = ?
+		GROUP BY tp.method
+		ORDER BY tp.method
+	`, id).Scan(&sum.Payments).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.ShiftSummary{}, err
+	}
+
+	// Refund dari void/refund transaksi & retur parsial yang uangnya keluar di shift ini
+	if err := conn(ctx, r.db).Raw(`
+		SELECT method, COUNT(*) AS refund_count, COALESCE(SUM(amount), 0) AS amount
+		FROM (
+			SELECT refund_method AS method, refund_amount AS amount
+			FROM transaction
+			WHERE refund_shift_id = ? AND refund_amount > 0
+			UNION ALL
+			SELECT refund_method AS method, refund_amount AS amount
+			FROM transaction_return
+			WHERE shift_id = ? AND refund_amount > 0
+		) r
+		GROUP BY method
+		ORDER BY method
+	`, id, id).Scan(&sum.Refunds).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.ShiftSummary{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return sum, nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// storeSettingID baris tunggal store_setting, di-seed oleh migration.
+const storeSettingID = 1
+
+type storeSettingRepo struct {
+	db *gorm.DB
+}
+
+func NewStoreSettingRepository(db *gorm.DB) *storeSettingRepo {
+	return &storeSettingRepo{db: db}
+}
+
+func (r *storeSettingRepo) Get(ctx context.Context) (entity.StoreSetting, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "StoreSettingRepository.Get"),
+	)
+
+	log.Info("in")
+
+	var s entity.StoreSetting
+	if err := conn(ctx, r.db).Where("id = ?", storeSettingID).Take(&s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.StoreSetting{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.StoreSetting{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return s, nil
+}
+
+func (r *storeSettingRepo) Update(ctx context.Context, s entity.StoreSetting) (entity.StoreSetting, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "StoreSettingRepository.Update"),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.StoreSetting{}).
+		Where("id = ?", storeSettingID).
+		Updates(map[string]interface{}{
+			"name":                 s.Name,
+			"address":              s.Address,
+			"phone":                s.Phone,
+			"npwp":                 s.NPWP,
+			"receipt_footer":       s.ReceiptFooter,
+			"invoice_template":     s.InvoiceTemplate,
+			"invoice_pdf_template": s.InvoicePDFTemplate,
+		})
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.StoreSetting{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.StoreSetting{}, repository.ErrNotFound
+	}
+
+	var current entity.StoreSetting
+	if err := conn(ctx, r.db).Where("id = ?", storeSettingID).Take(&current).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.StoreSetting{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
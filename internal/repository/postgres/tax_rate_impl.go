@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type taxRateRepo struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) *taxRateRepo {
+	return &taxRateRepo{db: db}
+}
+
+func (r *taxRateRepo) Create(ctx context.Context, t entity.TaxRate) (entity.TaxRate, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TaxRateRepository.Create"),
+		zap.String("name", t.Name),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&t).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.TaxRate{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.TaxRate{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("tax_rate_id", t.ID))
+
+	return t, nil
+}
+
+func (r *taxRateRepo) FindByID(ctx context.Context, id uint) (entity.TaxRate, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TaxRateRepository.FindByID"),
+		zap.Uint("tax_rate_id", id),
+	)
+
+	log.Info("in")
+
+	var t entity.TaxRate
+	if err := conn(ctx, r.db).Take(&t, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.TaxRate{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.TaxRate{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return t, nil
+}
+
+func (r *taxRateRepo) FindAll(ctx context.Context) ([]entity.TaxRate, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TaxRateRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	var rates []entity.TaxRate
+	if err := conn(ctx, r.db).Order("id").Find(&rates).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(rates)))
+
+	return rates, nil
+}
+
+// Update replace semua field, termasuk target category/product (boleh jadi NULL).
+func (r *taxRateRepo) Update(ctx context.Context, t entity.TaxRate) (entity.TaxRate, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TaxRateRepository.Update"),
+		zap.Uint("tax_rate_id", t.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.TaxRate{}).
+		Where("id = ?", t.ID).
+		Updates(map[string]interface{}{
+			"name":        t.Name,
+			"rate":        t.Rate,
+			"inclusive":   t.Inclusive,
+			"category_id": t.CategoryID,
+			"product_id":  t.ProductID,
+		})
+	if res.Error != nil {
+		if isDuplicatedKey(res.Error) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.TaxRate{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.TaxRate{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.TaxRate{}, repository.ErrNotFound
+	}
+
+	var current entity.TaxRate
+	if err := conn(ctx, r.db).First(&current, t.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.TaxRate{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+func (r *taxRateRepo) Delete(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TaxRateRepository.Delete"),
+		zap.Uint("tax_rate_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).Delete(&entity.TaxRate{}, id)
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
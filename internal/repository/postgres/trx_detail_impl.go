@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -17,21 +19,111 @@ func NewTrxDetailRepository(db *gorm.DB) *trxDetailRepository {
 	return &trxDetailRepository{db: db}
 }
 
-func (r *trxDetailRepository) Create(ctx context.Context, trx entity.TransactionDetail) (entity.TransactionDetail, error) {
+func (r *trxDetailRepository) CreateBatch(ctx context.Context, details []entity.TransactionDetail) ([]entity.TransactionDetail, error) {
 	log := middleware.LoggerFromCtx(ctx).With(
 		zap.String("layer", "repository"),
-		zap.String("operation", "TrxDetailRepository.Create"),
-		zap.Uint("id", trx.ID),
+		zap.String("operation", "TrxDetailRepository.CreateBatch"),
+		zap.Int("count", len(details)),
 	)
 
 	log.Info("in")
 
-	if err := r.db.WithContext(ctx).Create(&trx).Error; err != nil {
+	if len(details) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return details, nil
+	}
+
+	if err := conn(ctx, r.db).Create(&details).Error; err != nil {
 		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
-		return entity.TransactionDetail{}, err
+		return nil, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return details, nil
+}
+
+func (r *trxDetailRepository) FindByTransactionIDs(ctx context.Context, trxIDs []uint) ([]dto.TransactionDetail, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxDetailRepository.FindByTransactionIDs"),
+		zap.Int("transaction_count", len(trxIDs)),
+	)
+
+	log.Info("in")
+
+	details := []dto.TransactionDetail{}
+	if len(trxIDs) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return details, nil
+	}
+
+	if err := conn(ctx, r.db).
+		Table("transaction_detail td").
+		Select(`
+			td.id,
+			td.transaction_id,
+			td.product_id,
+			td.product_name,
+			COALESCE(td.category_id, 0) AS category_id,
+			td.category_name,
+			td.base_price,
+			td.unit_price,
+			td.price_list_id,
+			td.price_list_name,
+			td.quantity,
+			td.returned_quantity,
+			td.discount_amount,
+			td.cart_discount_amount,
+			td.promotion_amount,
+			td.promotion_name,
+			td.voucher_amount,
+			td.subtotal,
+			td.service_charge_amount,
+			td.tax_name,
+			td.tax_rate,
+			td.tax_inclusive,
+			td.tax_base_amount,
+			td.tax_amount
+		`).
+		Where("td.transaction_id IN ?", trxIDs).
+		Order("td.transaction_id, td.id").
+		Scan(&details).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(details)))
+
+	return details, nil
+}
+
+// AddReturnedQuantity menambah returned_quantity, ditolak kalau total retur melebihi quantity terjual.
+func (r *trxDetailRepository) AddReturnedQuantity(ctx context.Context, id uint, qty int) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxDetailRepository.AddReturnedQuantity"),
+		zap.Uint("transaction_detail_id", id),
+		zap.Int("quantity", qty),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.TransactionDetail{}).
+		Where("id = ? AND returned_quantity + ? <= quantity", id, qty).
+		Update("returned_quantity", gorm.Expr("returned_quantity + ?", qty))
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "conflict"))
+		return repository.ErrConflict
 	}
 
-	log.Info("out", zap.String("result", "ok"), zap.Uint("transaction_detail_id", trx.ID))
+	log.Info("out", zap.String("result", "ok"))
 
-	return trx, nil
+	return nil
 }
@@ -2,11 +2,17 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type trxRepo struct {
@@ -26,7 +32,7 @@ func (r *trxRepo) Create(ctx context.Context, trx entity.Transaction) (entity.Tr
 
 	log.Info("in")
 
-	if err := r.db.WithContext(ctx).Create(&trx).Error; err != nil {
+	if err := conn(ctx, r.db).Create(&trx).Error; err != nil {
 		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
 		return entity.Transaction{}, err
 	}
@@ -35,3 +41,209 @@ func (r *trxRepo) Create(ctx context.Context, trx entity.Transaction) (entity.Tr
 
 	return trx, nil
 }
+
+func (r *trxRepo) FindByID(ctx context.Context, id uint) (entity.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxRepository.FindByID"),
+		zap.Uint("transaction_id", id),
+	)
+
+	log.Info("in")
+
+	var trx entity.Transaction
+	if err := conn(ctx, r.db).Take(&trx, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Transaction{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Transaction{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return trx, nil
+}
+
+// LockByID ambil transaction dengan row lock (SELECT ... FOR UPDATE), harus dipanggil di dalam TxManager.
+func (r *trxRepo) LockByID(ctx context.Context, id uint) (entity.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxRepository.LockByID"),
+		zap.Uint("transaction_id", id),
+	)
+
+	log.Info("in")
+
+	var trx entity.Transaction
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&trx, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Transaction{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Transaction{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return trx, nil
+}
+
+func (r *trxRepo) FindAll(ctx context.Context, f dto.TransactionFilter) ([]entity.Transaction, int64, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	q := conn(ctx, r.db).Model(&entity.Transaction{})
+
+	if f.StartDate != "" {
+		q = q.Where("created_at >= ?::date", f.StartDate)
+	}
+	if f.EndDate != "" {
+		q = q.Where("created_at < ?::date + INTERVAL '1 day'", f.EndDate)
+	}
+	if f.MinAmount != nil {
+		q = q.Where("total_amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		q = q.Where("total_amount <= ?", *f.MaxAmount)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.ShiftID != 0 {
+		q = q.Where("shift_id = ?", f.ShiftID)
+	}
+	if f.CustomerID != 0 {
+		q = q.Where("customer_id = ?", f.CustomerID)
+	}
+	if f.Invoice != "" {
+		q = q.Where("invoice_number ILIKE ?", "%"+escapeLike(f.Invoice)+"%")
+	}
+	if f.ProductID != 0 {
+		q = q.Where(`EXISTS (
+			SELECT 1 FROM transaction_detail td
+			WHERE td.transaction_id = "transaction".id AND td.product_id = ?
+		)`, f.ProductID)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	var trxs []entity.Transaction
+	if err := q.
+		Order("created_at DESC, id DESC").
+		Limit(f.Limit).
+		Offset((f.Page - 1) * f.Limit).
+		Find(&trxs).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, 0, err
+	}
+
+	log.Info("out", zap.Int("count", len(trxs)), zap.Int64("total", total))
+
+	return trxs, total, nil
+}
+
+// UpdateStatus pindah status hanya kalau status sekarang masih `from`,
+// jadi void/refund yang dobel (atau paralel) cuma berhasil sekali.
+func (r *trxRepo) UpdateStatus(ctx context.Context, id uint, from string, to string, reason string) (entity.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxRepository.UpdateStatus"),
+		zap.Uint("transaction_id", id),
+		zap.String("from", from),
+		zap.String("to", to),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Transaction{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":            to,
+			"status_reason":     reason,
+			"status_updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Transaction{}, res.Error
+	}
+
+	var trx entity.Transaction
+	if err := conn(ctx, r.db).Take(&trx, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Transaction{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Transaction{}, err
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "conflict"), zap.String("status", trx.Status))
+		return entity.Transaction{}, repository.ErrConflict
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return trx, nil
+}
+
+// RecordRefund simpan nominal, method & shift pengembalian uang serta piutang yang dilepas saat void/refund.
+func (r *trxRepo) RecordRefund(ctx context.Context, id uint, shiftID *uint, method string, amount int, creditReleased int) (entity.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxRepository.RecordRefund"),
+		zap.Uint("transaction_id", id),
+		zap.String("method", method),
+		zap.Int("amount", amount),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Transaction{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"refund_shift_id": shiftID,
+			"refund_method":   method,
+			"refund_amount":   amount,
+			"credit_released": creditReleased,
+		})
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Transaction{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.Transaction{}, repository.ErrNotFound
+	}
+
+	var trx entity.Transaction
+	if err := conn(ctx, r.db).Take(&trx, id).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Transaction{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return trx, nil
+}
+
+// escapeLike escape wildcard LIKE supaya input user dicari apa adanya.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type trxPaymentRepo struct {
+	db *gorm.DB
+}
+
+func NewTrxPaymentRepository(db *gorm.DB) *trxPaymentRepo {
+	return &trxPaymentRepo{db: db}
+}
+
+func (r *trxPaymentRepo) CreateBatch(ctx context.Context, payments []entity.TransactionPayment) ([]entity.TransactionPayment, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxPaymentRepository.CreateBatch"),
+		zap.Int("count", len(payments)),
+	)
+
+	log.Info("in")
+
+	if len(payments) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return payments, nil
+	}
+
+	if err := conn(ctx, r.db).Create(&payments).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return payments, nil
+}
+
+func (r *trxPaymentRepo) FindByTransactionIDs(ctx context.Context, trxIDs []uint) ([]entity.TransactionPayment, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxPaymentRepository.FindByTransactionIDs"),
+		zap.Int("transaction_count", len(trxIDs)),
+	)
+
+	log.Info("in")
+
+	var payments []entity.TransactionPayment
+	if len(trxIDs) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return payments, nil
+	}
+
+	if err := conn(ctx, r.db).
+		Where("transaction_id IN ?", trxIDs).
+		Order("transaction_id, id").
+		Find(&payments).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(payments)))
+
+	return payments, nil
+}
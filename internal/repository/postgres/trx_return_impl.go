@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type trxReturnRepo struct {
+	db *gorm.DB
+}
+
+func NewTrxReturnRepository(db *gorm.DB) *trxReturnRepo {
+	return &trxReturnRepo{db: db}
+}
+
+func (r *trxReturnRepo) Create(ctx context.Context, ret entity.TransactionReturn) (entity.TransactionReturn, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxReturnRepository.Create"),
+		zap.Uint("transaction_id", ret.TransactionID),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&ret).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.TransactionReturn{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("return_id", ret.ID))
+
+	return ret, nil
+}
+
+func (r *trxReturnRepo) CreateDetails(ctx context.Context, items []entity.TransactionReturnDetail) ([]entity.TransactionReturnDetail, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxReturnRepository.CreateDetails"),
+		zap.Int("count", len(items)),
+	)
+
+	log.Info("in")
+
+	if len(items) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return items, nil
+	}
+
+	if err := conn(ctx, r.db).Create(&items).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return items, nil
+}
+
+func (r *trxReturnRepo) FindByTransactionID(ctx context.Context, trxID uint) ([]entity.TransactionReturn, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxReturnRepository.FindByTransactionID"),
+		zap.Uint("transaction_id", trxID),
+	)
+
+	log.Info("in")
+
+	var returns []entity.TransactionReturn
+	if err := conn(ctx, r.db).
+		Where("transaction_id = ?", trxID).
+		Order("id").
+		Find(&returns).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(returns)))
+
+	return returns, nil
+}
+
+func (r *trxReturnRepo) FindDetailsByReturnIDs(ctx context.Context, returnIDs []uint) ([]dto.TransactionReturnItem, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "TrxReturnRepository.FindDetailsByReturnIDs"),
+		zap.Int("return_count", len(returnIDs)),
+	)
+
+	log.Info("in")
+
+	items := []dto.TransactionReturnItem{}
+	if len(returnIDs) == 0 {
+		log.Info("out", zap.String("result", "empty_input"))
+		return items, nil
+	}
+
+	if err := conn(ctx, r.db).
+		Table("transaction_return_detail rd").
+		Select(`
+			rd.id,
+			rd.return_id,
+			rd.transaction_detail_id,
+			rd.product_id,
+			td.product_name,
+			rd.quantity,
+			rd.refund_amount
+		`).
+		Joins("JOIN transaction_detail td ON td.id = rd.transaction_detail_id").
+		Where("rd.return_id IN ?", returnIDs).
+		Order("rd.return_id, rd.id").
+		Scan(&items).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(items)))
+
+	return items, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) *txManager {
+	return &txManager{db: db}
+}
+
+// WithinTransaction commit kalau fn sukses, rollback kalau fn return error / panic.
+// Kalau ctx sudah membawa transaction, fn ikut transaction tersebut (tidak nested).
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn dipakai semua repository: pakai transaction dari ctx kalau ada, selain itu pakai db biasa.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
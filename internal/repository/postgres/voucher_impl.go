@@ -0,0 +1,385 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type voucherRepo struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) *voucherRepo {
+	return &voucherRepo{db: db}
+}
+
+// Create simpan voucher beserta category-nya, harus dipanggil di dalam TxManager.
+func (r *voucherRepo) Create(ctx context.Context, v entity.Voucher) (entity.Voucher, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.Create"),
+		zap.String("code", v.Code),
+	)
+
+	log.Info("in")
+
+	if err := conn(ctx, r.db).Create(&v).Error; err != nil {
+		if isDuplicatedKey(err) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.Voucher{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	if err := r.saveCategories(ctx, v.ID, v.CategoryIDs); err != nil {
+		log.Error("out", zap.String("result", "insert_categories_failed"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("voucher_id", v.ID))
+
+	return v, nil
+}
+
+func (r *voucherRepo) FindByID(ctx context.Context, id uint) (entity.Voucher, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.FindByID"),
+		zap.Uint("voucher_id", id),
+	)
+
+	log.Info("in")
+
+	var v entity.Voucher
+	if err := conn(ctx, r.db).Take(&v, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Voucher{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	vouchers := []entity.Voucher{v}
+	if err := r.loadCategories(ctx, vouchers); err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return vouchers[0], nil
+}
+
+func (r *voucherRepo) FindByCode(ctx context.Context, code string) (entity.Voucher, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.FindByCode"),
+		zap.String("code", code),
+	)
+
+	log.Info("in")
+
+	var v entity.Voucher
+	if err := conn(ctx, r.db).Where("code = ?", code).Take(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("out", zap.String("result", "not_found"))
+			return entity.Voucher{}, repository.ErrNotFound
+		}
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	vouchers := []entity.Voucher{v}
+	if err := r.loadCategories(ctx, vouchers); err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("voucher_id", v.ID))
+
+	return vouchers[0], nil
+}
+
+func (r *voucherRepo) FindAll(ctx context.Context) ([]entity.Voucher, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.FindAll"),
+	)
+
+	log.Info("in")
+
+	var vouchers []entity.Voucher
+	if err := conn(ctx, r.db).Order("id DESC").Find(&vouchers).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.loadCategories(ctx, vouchers); err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("out", zap.Int("count", len(vouchers)))
+
+	return vouchers, nil
+}
+
+// Update replace semua field voucher kecuali used_count, category diganti seluruhnya.
+// Harus dipanggil di dalam TxManager.
+func (r *voucherRepo) Update(ctx context.Context, v entity.Voucher) (entity.Voucher, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.Update"),
+		zap.Uint("voucher_id", v.ID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Voucher{}).
+		Where("id = ?", v.ID).
+		Updates(map[string]interface{}{
+			"code":               v.Code,
+			"description":        v.Description,
+			"type":               v.Type,
+			"value":              v.Value,
+			"max_discount":       v.MaxDiscount,
+			"min_spend":          v.MinSpend,
+			"expires_at":         v.ExpiresAt,
+			"usage_limit":        v.UsageLimit,
+			"per_customer_limit": v.PerCustomerLimit,
+			"active":             v.Active,
+		})
+	if res.Error != nil {
+		if isDuplicatedKey(res.Error) {
+			log.Info("out", zap.String("result", "conflict"))
+			return entity.Voucher{}, repository.ErrConflict
+		}
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.Voucher{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return entity.Voucher{}, repository.ErrNotFound
+	}
+
+	if err := conn(ctx, r.db).
+		Where("voucher_id = ?", v.ID).
+		Delete(&entity.VoucherCategory{}).Error; err != nil {
+		log.Error("out", zap.String("result", "delete_categories_failed"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	if err := r.saveCategories(ctx, v.ID, v.CategoryIDs); err != nil {
+		log.Error("out", zap.String("result", "insert_categories_failed"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+
+	var current entity.Voucher
+	if err := conn(ctx, r.db).Take(&current, v.ID).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error_after_update"), zap.Error(err))
+		return entity.Voucher{}, err
+	}
+	current.CategoryIDs = v.CategoryIDs
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return current, nil
+}
+
+// Delete ditolak kalau voucher sudah pernah dipakai transaksi.
+func (r *voucherRepo) Delete(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.Delete"),
+		zap.Uint("voucher_id", id),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).Delete(&entity.Voucher{}, id)
+	if res.Error != nil {
+		if isForeignKeyViolated(res.Error) {
+			log.Info("out", zap.String("result", "forbidden"))
+			return repository.ErrForbidden
+		}
+		log.Error("out", zap.String("result", "delete_failed"), zap.Error(res.Error))
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "not_found"))
+		return repository.ErrNotFound
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// CountRedemptions jumlah pemakaian voucher oleh customer yang belum di-release.
+func (r *voucherRepo) CountRedemptions(ctx context.Context, voucherID uint, customerID uint) (int, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.CountRedemptions"),
+		zap.Uint("voucher_id", voucherID),
+		zap.Uint("customer_id", customerID),
+	)
+
+	log.Info("in")
+
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&entity.VoucherRedemption{}).
+		Where("voucher_id = ? AND customer_id = ? AND status = ?", voucherID, customerID, entity.RedemptionRedeemed).
+		Count(&count).Error; err != nil {
+		log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+		return 0, err
+	}
+
+	log.Info("out", zap.Int64("count", count))
+
+	return int(count), nil
+}
+
+// Redeem naikkan used_count secara conditional lalu catat redemption, harus dipanggil di dalam TxManager.
+// UPDATE pertama sekaligus me-lock row voucher sampai commit, jadi checkout paralel dengan kode yang sama
+// antri di sini dan hitungan per customer di bawahnya tidak bisa balapan.
+func (r *voucherRepo) Redeem(ctx context.Context, v entity.Voucher, red entity.VoucherRedemption) (entity.VoucherRedemption, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.Redeem"),
+		zap.Uint("voucher_id", v.ID),
+		zap.Uint("transaction_id", red.TransactionID),
+	)
+
+	log.Info("in")
+
+	res := conn(ctx, r.db).
+		Model(&entity.Voucher{}).
+		Where("id = ? AND active", v.ID).
+		Where("usage_limit = 0 OR used_count < usage_limit").
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if res.Error != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(res.Error))
+		return entity.VoucherRedemption{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		log.Info("out", zap.String("result", "unavailable"))
+		return entity.VoucherRedemption{}, repository.ErrVoucherUnavailable
+	}
+
+	if v.PerCustomerLimit > 0 && red.CustomerID != nil {
+		used, err := r.CountRedemptions(ctx, v.ID, *red.CustomerID)
+		if err != nil {
+			log.Error("out", zap.String("result", "db_error"), zap.Error(err))
+			return entity.VoucherRedemption{}, err
+		}
+		if used >= v.PerCustomerLimit {
+			log.Info("out", zap.String("result", "customer_limit_reached"))
+			return entity.VoucherRedemption{}, repository.ErrVoucherUnavailable
+		}
+	}
+
+	red.VoucherID = v.ID
+	red.Status = entity.RedemptionRedeemed
+	if err := conn(ctx, r.db).Create(&red).Error; err != nil {
+		log.Error("out", zap.String("result", "insert_failed"), zap.Error(err))
+		return entity.VoucherRedemption{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("redemption_id", red.ID))
+
+	return red, nil
+}
+
+// Release batalkan redemption transaksi (void/refund) dan kembalikan kuota voucher.
+// Transaksi tanpa voucher atau yang sudah di-release dilewati.
+func (r *voucherRepo) Release(ctx context.Context, transactionID uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "repository"),
+		zap.String("operation", "VoucherRepository.Release"),
+		zap.Uint("transaction_id", transactionID),
+	)
+
+	log.Info("in")
+
+	var voucherIDs []uint
+	if err := conn(ctx, r.db).Raw(`
+		UPDATE voucher_redemption
+		SET status = ?, released_at = NOW()
+		WHERE transaction_id = ? AND status = ?
+		RETURNING voucher_id
+	`, entity.RedemptionReleased, transactionID, entity.RedemptionRedeemed).Scan(&voucherIDs).Error; err != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(err))
+		return err
+	}
+
+	if len(voucherIDs) == 0 {
+		log.Info("out", zap.String("result", "no_redemption"))
+		return nil
+	}
+
+	if err := conn(ctx, r.db).
+		Model(&entity.Voucher{}).
+		Where("id = ? AND used_count > 0", voucherIDs[0]).
+		UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+		log.Error("out", zap.String("result", "update_failed"), zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("voucher_id", voucherIDs[0]))
+
+	return nil
+}
+
+func (r *voucherRepo) saveCategories(ctx context.Context, voucherID uint, categoryIDs []uint) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.VoucherCategory, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		rows = append(rows, entity.VoucherCategory{VoucherID: voucherID, CategoryID: id})
+	}
+	return conn(ctx, r.db).Create(&rows).Error
+}
+
+func (r *voucherRepo) loadCategories(ctx context.Context, vouchers []entity.Voucher) error {
+	if len(vouchers) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(vouchers))
+	index := make(map[uint]int, len(vouchers))
+	for i, v := range vouchers {
+		ids = append(ids, v.ID)
+		index[v.ID] = i
+	}
+
+	var rows []entity.VoucherCategory
+	if err := conn(ctx, r.db).
+		Where("voucher_id IN ?", ids).
+		Order("voucher_id, category_id").
+		Find(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		v := &vouchers[index[row.VoucherID]]
+		v.CategoryIDs = append(v.CategoryIDs, row.CategoryID)
+	}
+	return nil
+}
@@ -13,4 +13,9 @@ type ProductRepository interface {
 	Update(ctx context.Context, p entity.Product) (entity.Product, error)
 	Delete(ctx context.Context, id uint) error
 	FindDetailByID(ctx context.Context, id uint) (dto.ProductDetailResponse, error)
+	FindDetailByIDs(ctx context.Context, ids []uint) ([]dto.ProductDetailResponse, error)
+	DecreaseStockBatch(ctx context.Context, qtyByID map[uint]int) error
+	IncreaseStock(ctx context.Context, id uint, qty int) error
+	FindPriceTiers(ctx context.Context, productIDs []uint) ([]entity.ProductPriceTier, error)
+	ReplacePriceTiers(ctx context.Context, productID uint, tiers []entity.ProductPriceTier) ([]entity.ProductPriceTier, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+	"time"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, p entity.Promotion) (entity.Promotion, error)
+	FindByID(ctx context.Context, id uint) (entity.Promotion, error)
+	FindAll(ctx context.Context) ([]entity.Promotion, error)
+	Update(ctx context.Context, p entity.Promotion) (entity.Promotion, error)
+	Delete(ctx context.Context, id uint) error
+	FindActive(ctx context.Context, at time.Time) ([]entity.Promotion, error)
+}
@@ -7,4 +7,6 @@ import (
 
 type ReportRepository interface {
 	GetReport(ctx context.Context, startDate string, endDate string) (entity.Report, error)
+	FindCreditSales(ctx context.Context, customerID uint, asOf string) ([]entity.CreditSale, error)
+	SumCreditRepayments(ctx context.Context, customerID uint, asOf string) ([]entity.CreditRepaid, error)
 }
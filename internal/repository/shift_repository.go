@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type ShiftRepository interface {
+	Create(ctx context.Context, s entity.Shift) (entity.Shift, error)
+	FindByID(ctx context.Context, id uint) (entity.Shift, error)
+	LockByID(ctx context.Context, id uint) (entity.Shift, error)
+	FindOpenByTerminal(ctx context.Context, terminalID string) (entity.Shift, error)
+	Close(ctx context.Context, s entity.Shift) (entity.Shift, error)
+	Summary(ctx context.Context, id uint) (entity.ShiftSummary, error)
+}
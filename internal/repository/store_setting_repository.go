@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type StoreSettingRepository interface {
+	Get(ctx context.Context) (entity.StoreSetting, error)
+	Update(ctx context.Context, s entity.StoreSetting) (entity.StoreSetting, error)
+}
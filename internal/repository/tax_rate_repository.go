@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type TaxRateRepository interface {
+	Create(ctx context.Context, t entity.TaxRate) (entity.TaxRate, error)
+	FindByID(ctx context.Context, id uint) (entity.TaxRate, error)
+	FindAll(ctx context.Context) ([]entity.TaxRate, error)
+	Update(ctx context.Context, t entity.TaxRate) (entity.TaxRate, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
 )
 
 type TrxDetailRepository interface {
-	Create(ctx context.Context, trx entity.TransactionDetail) (entity.TransactionDetail, error)
+	CreateBatch(ctx context.Context, details []entity.TransactionDetail) ([]entity.TransactionDetail, error)
+	FindByTransactionIDs(ctx context.Context, trxIDs []uint) ([]dto.TransactionDetail, error)
+	AddReturnedQuantity(ctx context.Context, id uint, qty int) error
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type TrxPaymentRepository interface {
+	CreateBatch(ctx context.Context, payments []entity.TransactionPayment) ([]entity.TransactionPayment, error)
+	FindByTransactionIDs(ctx context.Context, trxIDs []uint) ([]entity.TransactionPayment, error)
+}
@@ -2,9 +2,15 @@ package repository
 
 import (
 	"context"
+	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
 )
 
 type TrxRepository interface {
 	Create(ctx context.Context, trx entity.Transaction) (entity.Transaction, error)
+	FindByID(ctx context.Context, id uint) (entity.Transaction, error)
+	LockByID(ctx context.Context, id uint) (entity.Transaction, error)
+	FindAll(ctx context.Context, f dto.TransactionFilter) ([]entity.Transaction, int64, error)
+	UpdateStatus(ctx context.Context, id uint, from string, to string, reason string) (entity.Transaction, error)
+	RecordRefund(ctx context.Context, id uint, shiftID *uint, method string, amount int, creditReleased int) (entity.Transaction, error)
 }
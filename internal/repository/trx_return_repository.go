@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+)
+
+type TrxReturnRepository interface {
+	Create(ctx context.Context, ret entity.TransactionReturn) (entity.TransactionReturn, error)
+	CreateDetails(ctx context.Context, items []entity.TransactionReturnDetail) ([]entity.TransactionReturnDetail, error)
+	FindByTransactionID(ctx context.Context, trxID uint) ([]entity.TransactionReturn, error)
+	FindDetailsByReturnIDs(ctx context.Context, returnIDs []uint) ([]dto.TransactionReturnItem, error)
+}
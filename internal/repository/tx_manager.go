@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// TxManager menjalankan beberapa operasi repository dalam satu database transaction.
+// Repository yang dipanggil dengan ctx dari fn otomatis ikut transaction yang sama.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"kasir-api/internal/entity"
+)
+
+type VoucherRepository interface {
+	Create(ctx context.Context, v entity.Voucher) (entity.Voucher, error)
+	FindByID(ctx context.Context, id uint) (entity.Voucher, error)
+	FindByCode(ctx context.Context, code string) (entity.Voucher, error)
+	FindAll(ctx context.Context) ([]entity.Voucher, error)
+	Update(ctx context.Context, v entity.Voucher) (entity.Voucher, error)
+	Delete(ctx context.Context, id uint) error
+	CountRedemptions(ctx context.Context, voucherID uint, customerID uint) (int, error)
+	Redeem(ctx context.Context, v entity.Voucher, red entity.VoucherRedemption) (entity.VoucherRedemption, error)
+	Release(ctx context.Context, transactionID uint) error
+}
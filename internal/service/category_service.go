@@ -207,6 +207,11 @@ func (s *categoryService) DeleteCategoryByID(ctx context.Context, id uint) error
 			return Forbidden("Default category cannot be deleted")
 		}
 
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return Conflict("Category is still used by a voucher")
+		}
+
 		log.Error("out", zap.Error(err))
 		return err
 	}
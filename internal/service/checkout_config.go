@@ -0,0 +1,66 @@
+package service
+
+import "fmt"
+
+const (
+	RoundingNone    = "none"
+	RoundingNearest = "nearest"
+	RoundingUp      = "up"
+	RoundingDown    = "down"
+)
+
+// CheckoutConfig aturan perhitungan checkout yang diatur dari config (bukan dari request).
+type CheckoutConfig struct {
+	Rounding RoundingPolicy
+	// ServiceChargeRate persen service charge dari nilai setelah diskon, 0 berarti tidak dipakai.
+	ServiceChargeRate float64
+	Invoice           InvoiceConfig
+	Loyalty           LoyaltyConfig
+}
+
+// RoundingPolicy pembulatan total yang dibayar cash, misal ke Rp100 / Rp500 terdekat.
+type RoundingPolicy struct {
+	Mode      string
+	Increment int
+}
+
+// Validate cek mode & increment waktu startup, supaya typo di env tidak diam-diam mematikan pembulatan.
+func (p RoundingPolicy) Validate() error {
+	switch p.Mode {
+	case RoundingNone:
+		return nil
+	case RoundingNearest, RoundingUp, RoundingDown:
+		if p.Increment <= 0 {
+			return fmt.Errorf("rounding increment %d must be > 0 for mode %q", p.Increment, p.Mode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("rounding mode %q must be one of %q, %q, %q or %q", p.Mode, RoundingNone, RoundingNearest, RoundingUp, RoundingDown)
+	}
+}
+
+// Apply return amount setelah dibulatkan. Mode none / increment <= 1 berarti tanpa pembulatan.
+func (p RoundingPolicy) Apply(amount int) int {
+	if p.Increment <= 1 || amount <= 0 {
+		return amount
+	}
+
+	rem := amount % p.Increment
+	if rem == 0 {
+		return amount
+	}
+
+	switch p.Mode {
+	case RoundingDown:
+		return amount - rem
+	case RoundingUp:
+		return amount - rem + p.Increment
+	case RoundingNearest:
+		if rem*2 >= p.Increment {
+			return amount - rem + p.Increment
+		}
+		return amount - rem
+	default:
+		return amount
+	}
+}
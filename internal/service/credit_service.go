@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"math"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type CreditService interface {
+	CreateRepayment(ctx context.Context, customerID uint, req dto.CreateCreditRepayment) (dto.CreditRepayment, error)
+	GetCreditAccount(ctx context.Context, customerID uint, page int, limit int) (dto.CreditAccount, error)
+}
+
+type creditService struct {
+	txManager    repository.TxManager
+	customerRepo repository.CustomerRepository
+	creditRepo   repository.CreditRepository
+	shiftRepo    repository.ShiftRepository
+}
+
+func NewCreditService(txManager repository.TxManager, customerRepo repository.CustomerRepository, creditRepo repository.CreditRepository, shiftRepo repository.ShiftRepository) CreditService {
+	return &creditService{txManager: txManager, customerRepo: customerRepo, creditRepo: creditRepo, shiftRepo: shiftRepo}
+}
+
+// CreateRepayment catat pelunasan kasbon. Pelunasan cash wajib di terminal dengan shift open
+// karena uangnya masuk laci dan ikut expected cash shift tersebut.
+func (s *creditService) CreateRepayment(ctx context.Context, customerID uint, req dto.CreateCreditRepayment) (dto.CreditRepayment, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CreditService.CreateRepayment"),
+		zap.Uint("customer_id", customerID),
+	)
+
+	log.Info("in")
+
+	if req.Amount <= 0 {
+		log.Warn("out", zap.String("result", "invalid_amount"))
+		return dto.CreditRepayment{}, InvalidInput("Amount must be > 0")
+	}
+
+	method := strings.ToLower(strings.TrimSpace(req.Method))
+	if method == "" {
+		method = entity.PaymentMethodCash
+	}
+	if !paymentMethods[method] || method == entity.PaymentMethodCredit {
+		log.Warn("out", zap.String("result", "invalid_method"))
+		return dto.CreditRepayment{}, InvalidInput("Invalid payment method")
+	}
+
+	terminalID, err := normalizeTerminalID(req.TerminalID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.CreditRepayment{}, err
+	}
+	if terminalID == "" && method == entity.PaymentMethodCash {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.CreditRepayment{}, InvalidInput("Terminal ID is required for cash repayment")
+	}
+
+	var res dto.CreditRepayment
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var shiftID *uint
+		if terminalID != "" {
+			// FOR SHARE: tutup shift menunggu pelunasan ini commit supaya ikut dihitung
+			shift, err := s.shiftRepo.FindOpenByTerminal(ctx, terminalID)
+			switch {
+			case err == nil:
+				shiftID = &shift.ID
+			case !errors.Is(err, repository.ErrNotFound):
+				log.Error("out", zap.Error(err))
+				return err
+			case method == entity.PaymentMethodCash:
+				log.Warn("out", zap.String("result", "no_open_shift"))
+				return BadRequest("No open shift on terminal")
+			}
+		}
+
+		created, err := s.creditRepo.CreateRepayment(ctx, entity.CreditRepayment{
+			CustomerID: customerID,
+			ShiftID:    shiftID,
+			TerminalID: terminalID,
+			Method:     method,
+			Amount:     req.Amount,
+			Reference:  strings.TrimSpace(req.Reference),
+			Note:       strings.TrimSpace(req.Note),
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "not_found"))
+				return NotFound("Customer not found")
+			}
+			if errors.Is(err, repository.ErrOverpayment) {
+				log.Warn("out", zap.String("result", "overpayment"))
+				return BadRequest("Amount exceeds credit balance")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		res = toCreditRepaymentDTO(created)
+
+		return nil
+	})
+	if err != nil {
+		return dto.CreditRepayment{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("repayment_id", res.ID))
+
+	return res, nil
+}
+
+func (s *creditService) GetCreditAccount(ctx context.Context, customerID uint, page int, limit int) (dto.CreditAccount, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CreditService.GetCreditAccount"),
+		zap.Uint("customer_id", customerID),
+	)
+
+	log.Info("in")
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	c, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CreditAccount{}, NotFound("Customer not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CreditAccount{}, err
+	}
+
+	repayments, total, err := s.creditRepo.FindRepaymentsByCustomerID(ctx, customerID, page, limit)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CreditAccount{}, err
+	}
+
+	items := make([]dto.CreditRepayment, 0, len(repayments))
+	for _, r := range repayments {
+		items = append(items, toCreditRepaymentDTO(r))
+	}
+
+	res := dto.CreditAccount{
+		CustomerID:    c.ID,
+		CreditLimit:   c.CreditLimit,
+		CreditBalance: c.CreditBalance,
+		Available:     max(c.CreditLimit-c.CreditBalance, 0),
+		Repayments:    items,
+		Pagination: dto.Pagination{
+			Page:      page,
+			Limit:     limit,
+			TotalData: total,
+			TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		},
+	}
+
+	log.Info("out", zap.Int("count", len(items)))
+
+	return res, nil
+}
+
+func toCreditRepaymentDTO(r entity.CreditRepayment) dto.CreditRepayment {
+	return dto.CreditRepayment{
+		ID:         r.ID,
+		CustomerID: r.CustomerID,
+		ShiftID:    r.ShiftID,
+		TerminalID: r.TerminalID,
+		Method:     r.Method,
+		Amount:     r.Amount,
+		Reference:  r.Reference,
+		Note:       r.Note,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type CustomerGroupService interface {
+	CreateCustomerGroup(ctx context.Context, req dto.CustomerGroup) (dto.CustomerGroupResponse, error)
+	GetCustomerGroupByID(ctx context.Context, id uint) (dto.CustomerGroupResponse, error)
+	GetAllCustomerGroup(ctx context.Context) ([]dto.CustomerGroupResponse, error)
+	UpdateCustomerGroupByID(ctx context.Context, id uint, req dto.CustomerGroup) (dto.CustomerGroupResponse, error)
+	DeleteCustomerGroupByID(ctx context.Context, id uint) error
+
+	CreateGroupPrice(ctx context.Context, groupID uint, req dto.GroupPrice) (dto.GroupPriceResponse, error)
+	UpdateGroupPrice(ctx context.Context, groupID uint, id uint, req dto.GroupPrice) (dto.GroupPriceResponse, error)
+	DeleteGroupPrice(ctx context.Context, groupID uint, id uint) error
+}
+
+type customerGroupService struct {
+	groupRepo    repository.CustomerGroupRepository
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewCustomerGroupService(groupRepo repository.CustomerGroupRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository) CustomerGroupService {
+	return &customerGroupService{groupRepo: groupRepo, categoryRepo: categoryRepo, productRepo: productRepo}
+}
+
+func (s *customerGroupService) CreateCustomerGroup(ctx context.Context, req dto.CustomerGroup) (dto.CustomerGroupResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.CreateCustomerGroup"),
+	)
+
+	log.Info("in")
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		log.Warn("out", zap.String("result", "invalid_input"))
+		return dto.CustomerGroupResponse{}, InvalidInput("Name is required")
+	}
+
+	created, err := s.groupRepo.Create(ctx, entity.CustomerGroup{Name: name, Description: strings.TrimSpace(req.Description)})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.CustomerGroupResponse{}, Conflict("Customer group name already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerGroupResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("customer_group_id", created.ID))
+
+	return toCustomerGroupDTO(created, nil), nil
+}
+
+// GetCustomerGroupByID grup beserta semua harga di price list-nya.
+func (s *customerGroupService) GetCustomerGroupByID(ctx context.Context, id uint) (dto.CustomerGroupResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.GetCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	g, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerGroupResponse{}, NotFound("Customer group not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerGroupResponse{}, err
+	}
+
+	prices, err := s.groupRepo.FindPrices(ctx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CustomerGroupResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toCustomerGroupDTO(g, prices), nil
+}
+
+func (s *customerGroupService) GetAllCustomerGroup(ctx context.Context) ([]dto.CustomerGroupResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.GetAllCustomerGroup"),
+	)
+
+	log.Info("in")
+
+	groups, err := s.groupRepo.FindAll(ctx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]dto.CustomerGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, toCustomerGroupDTO(g, nil))
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func (s *customerGroupService) UpdateCustomerGroupByID(ctx context.Context, id uint, req dto.CustomerGroup) (dto.CustomerGroupResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.UpdateCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		log.Warn("out", zap.String("result", "invalid_input"))
+		return dto.CustomerGroupResponse{}, InvalidInput("Name is required")
+	}
+
+	updated, err := s.groupRepo.Update(ctx, entity.CustomerGroup{ID: id, Name: name, Description: strings.TrimSpace(req.Description)})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerGroupResponse{}, NotFound("Customer group not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.CustomerGroupResponse{}, Conflict("Customer group name already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerGroupResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toCustomerGroupDTO(updated, nil), nil
+}
+
+func (s *customerGroupService) DeleteCustomerGroupByID(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.DeleteCustomerGroupByID"),
+	)
+
+	log.Info("in")
+
+	if err := s.groupRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Customer group not found")
+		}
+		if errors.Is(err, repository.ErrForbidden) {
+			log.Warn("out", zap.String("result", "forbidden"))
+			return Forbidden("Customer group still has customers")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (s *customerGroupService) CreateGroupPrice(ctx context.Context, groupID uint, req dto.GroupPrice) (dto.GroupPriceResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.CreateGroupPrice"),
+		zap.Uint("customer_group_id", groupID),
+	)
+
+	log.Info("in")
+
+	p, err := s.validatePrice(ctx, groupID, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.GroupPriceResponse{}, err
+	}
+
+	created, err := s.groupRepo.CreatePrice(ctx, p)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.GroupPriceResponse{}, Conflict("Price for this target already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.GroupPriceResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("group_price_id", created.ID))
+
+	return toGroupPriceDTO(created), nil
+}
+
+func (s *customerGroupService) UpdateGroupPrice(ctx context.Context, groupID uint, id uint, req dto.GroupPrice) (dto.GroupPriceResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.UpdateGroupPrice"),
+		zap.Uint("customer_group_id", groupID),
+	)
+
+	log.Info("in")
+
+	p, err := s.validatePrice(ctx, groupID, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.GroupPriceResponse{}, err
+	}
+	p.ID = id
+
+	updated, err := s.groupRepo.UpdatePrice(ctx, p)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.GroupPriceResponse{}, NotFound("Group price not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.GroupPriceResponse{}, Conflict("Price for this target already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.GroupPriceResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toGroupPriceDTO(updated), nil
+}
+
+func (s *customerGroupService) DeleteGroupPrice(ctx context.Context, groupID uint, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerGroupService.DeleteGroupPrice"),
+		zap.Uint("customer_group_id", groupID),
+	)
+
+	log.Info("in")
+
+	if err := s.groupRepo.DeletePrice(ctx, groupID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Group price not found")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (s *customerGroupService) validatePrice(ctx context.Context, groupID uint, req dto.GroupPrice) (entity.GroupPrice, error) {
+	if (req.ProductID == nil) == (req.CategoryID == nil) {
+		return entity.GroupPrice{}, InvalidInput("Group price must target either a product or a category")
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(req.Type))
+	switch kind {
+	case entity.GroupPriceFixed:
+		if req.Value < 0 {
+			return entity.GroupPrice{}, InvalidInput("Price must be >= 0")
+		}
+	case entity.GroupPricePercent:
+		if req.Value < 0 || req.Value > 100 {
+			return entity.GroupPrice{}, InvalidInput("Percent must be between 0 and 100")
+		}
+	default:
+		return entity.GroupPrice{}, InvalidInput("Invalid group price type")
+	}
+
+	if _, err := s.groupRepo.FindByID(ctx, groupID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.GroupPrice{}, NotFound("Customer group not found")
+		}
+		return entity.GroupPrice{}, err
+	}
+
+	if req.CategoryID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *req.CategoryID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.GroupPrice{}, NotFound("Category not found")
+			}
+			return entity.GroupPrice{}, err
+		}
+	}
+
+	if req.ProductID != nil {
+		if _, err := s.productRepo.FindByID(ctx, *req.ProductID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.GroupPrice{}, NotFound("Product not found")
+			}
+			return entity.GroupPrice{}, err
+		}
+	}
+
+	return entity.GroupPrice{
+		CustomerGroupID: groupID,
+		ProductID:       req.ProductID,
+		CategoryID:      req.CategoryID,
+		Type:            kind,
+		Value:           req.Value,
+	}, nil
+}
+
+func toCustomerGroupDTO(g entity.CustomerGroup, prices []entity.GroupPrice) dto.CustomerGroupResponse {
+	res := dto.CustomerGroupResponse{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+
+	if prices != nil {
+		res.Prices = make([]dto.GroupPriceResponse, 0, len(prices))
+		for _, p := range prices {
+			res.Prices = append(res.Prices, toGroupPriceDTO(p))
+		}
+	}
+
+	return res
+}
+
+func toGroupPriceDTO(p entity.GroupPrice) dto.GroupPriceResponse {
+	return dto.GroupPriceResponse{
+		ID:              p.ID,
+		CustomerGroupID: p.CustomerGroupID,
+		ProductID:       p.ProductID,
+		CategoryID:      p.CategoryID,
+		Type:            p.Type,
+		Value:           p.Value,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
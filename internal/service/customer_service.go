@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"math"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, req dto.Customer) (dto.CustomerResponse, error)
+	GetCustomerByID(ctx context.Context, id uint) (dto.CustomerResponse, error)
+	GetAllCustomer(ctx context.Context, f dto.CustomerFilter) (dto.CustomerList, error)
+	UpdateCustomerByID(ctx context.Context, id uint, req dto.Customer) (dto.CustomerResponse, error)
+	DeleteCustomerByID(ctx context.Context, id uint) error
+	GetCustomerTransactions(ctx context.Context, id uint, page int, limit int) (dto.CustomerHistory, error)
+	GetCustomerPoints(ctx context.Context, id uint, page int, limit int) (dto.CustomerPoints, error)
+}
+
+type customerService struct {
+	customerRepo repository.CustomerRepository
+	groupRepo    repository.CustomerGroupRepository
+	loyaltyRepo  repository.LoyaltyRepository
+	trxSvc       TrxService
+}
+
+func NewCustomerService(customerRepo repository.CustomerRepository, groupRepo repository.CustomerGroupRepository, loyaltyRepo repository.LoyaltyRepository, trxSvc TrxService) CustomerService {
+	return &customerService{customerRepo: customerRepo, groupRepo: groupRepo, loyaltyRepo: loyaltyRepo, trxSvc: trxSvc}
+}
+
+func (s *customerService) CreateCustomer(ctx context.Context, req dto.Customer) (dto.CustomerResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.CreateCustomer"),
+	)
+
+	log.Info("in")
+
+	c, err := validateCustomer(req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	if err := s.checkGroup(ctx, c.CustomerGroupID); err != nil {
+		log.Warn("out", zap.String("result", "invalid_group"), zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	created, err := s.customerRepo.Create(ctx, c)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.CustomerResponse{}, Conflict("Phone already registered")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("customer_id", created.ID))
+
+	return toCustomerDTO(created), nil
+}
+
+func (s *customerService) GetCustomerByID(ctx context.Context, id uint) (dto.CustomerResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.GetCustomerByID"),
+	)
+
+	log.Info("in")
+
+	c, err := s.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerResponse{}, NotFound("Customer not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toCustomerDTO(c), nil
+}
+
+func (s *customerService) GetAllCustomer(ctx context.Context, f dto.CustomerFilter) (dto.CustomerList, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.GetAllCustomer"),
+	)
+
+	log.Info("in")
+
+	f.Q = strings.TrimSpace(f.Q)
+	// nomor HP disimpan sudah dinormalisasi, jadi "0812-3456 789" harus dicari sebagai "08123456789"
+	f.Phone = normalizePhone(f.Q)
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.Limit <= 0 {
+		f.Limit = 20
+	}
+	if f.Limit > 100 {
+		f.Limit = 100
+	}
+
+	customers, total, err := s.customerRepo.FindAll(ctx, f)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CustomerList{}, err
+	}
+
+	items := make([]dto.CustomerResponse, 0, len(customers))
+	for _, c := range customers {
+		items = append(items, toCustomerDTO(c))
+	}
+
+	res := dto.CustomerList{
+		Items: items,
+		Pagination: dto.Pagination{
+			Page:      f.Page,
+			Limit:     f.Limit,
+			TotalData: total,
+			TotalPage: int(math.Ceil(float64(total) / float64(f.Limit))),
+		},
+	}
+
+	log.Info("out", zap.Int("count", len(items)))
+
+	return res, nil
+}
+
+func (s *customerService) UpdateCustomerByID(ctx context.Context, id uint, req dto.Customer) (dto.CustomerResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.UpdateCustomerByID"),
+	)
+
+	log.Info("in")
+
+	c, err := validateCustomer(req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	if err := s.checkGroup(ctx, c.CustomerGroupID); err != nil {
+		log.Warn("out", zap.String("result", "invalid_group"), zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+	c.ID = id
+
+	updated, err := s.customerRepo.Update(ctx, c)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerResponse{}, NotFound("Customer not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.CustomerResponse{}, Conflict("Phone already registered")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toCustomerDTO(updated), nil
+}
+
+func (s *customerService) DeleteCustomerByID(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.DeleteCustomerByID"),
+	)
+
+	log.Info("in")
+
+	if err := s.customerRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Customer not found")
+		}
+		if errors.Is(err, repository.ErrForbidden) {
+			log.Warn("out", zap.String("result", "forbidden"))
+			return Forbidden("Customer with transactions cannot be deleted")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+// GetCustomerTransactions riwayat belanja customer (paginated) beserta lifetime value.
+func (s *customerService) GetCustomerTransactions(ctx context.Context, id uint, page int, limit int) (dto.CustomerHistory, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.GetCustomerTransactions"),
+		zap.Uint("customer_id", id),
+	)
+
+	log.Info("in")
+
+	c, err := s.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerHistory{}, NotFound("Customer not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerHistory{}, err
+	}
+
+	stats, err := s.customerRepo.Stats(ctx, id)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CustomerHistory{}, err
+	}
+
+	trxs, err := s.trxSvc.GetAllTransaction(ctx, dto.TransactionFilter{CustomerID: id, Page: page, Limit: limit})
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CustomerHistory{}, err
+	}
+
+	res := dto.CustomerHistory{
+		Customer:         toCustomerDTO(c),
+		TransactionCount: stats.TransactionCount,
+		LifetimeValue:    stats.LifetimeValue,
+		FirstPurchaseAt:  stats.FirstPurchaseAt,
+		LastPurchaseAt:   stats.LastPurchaseAt,
+		Transactions:     trxs,
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return res, nil
+}
+
+// GetCustomerPoints saldo poin customer dan mutasi ledger terbaru lebih dulu.
+func (s *customerService) GetCustomerPoints(ctx context.Context, id uint, page int, limit int) (dto.CustomerPoints, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "CustomerService.GetCustomerPoints"),
+		zap.Uint("customer_id", id),
+	)
+
+	log.Info("in")
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	c, err := s.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.CustomerPoints{}, NotFound("Customer not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.CustomerPoints{}, err
+	}
+
+	entries, total, err := s.loyaltyRepo.FindByCustomerID(ctx, id, page, limit)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CustomerPoints{}, err
+	}
+
+	ledger := make([]dto.LoyaltyLedger, 0, len(entries))
+	for _, e := range entries {
+		ledger = append(ledger, dto.LoyaltyLedger{
+			ID:            e.ID,
+			TransactionID: e.TransactionID,
+			Type:          e.Type,
+			Points:        e.Points,
+			BalanceAfter:  e.BalanceAfter,
+			Note:          e.Note,
+			CreatedAt:     e.CreatedAt,
+		})
+	}
+
+	res := dto.CustomerPoints{
+		CustomerID:    c.ID,
+		PointsBalance: c.PointsBalance,
+		Ledger:        ledger,
+		Pagination: dto.Pagination{
+			Page:      page,
+			Limit:     limit,
+			TotalData: total,
+			TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		},
+	}
+
+	log.Info("out", zap.Int("count", len(ledger)))
+
+	return res, nil
+}
+
+func validateCustomer(req dto.Customer) (entity.Customer, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return entity.Customer{}, InvalidInput("Name is required")
+	}
+
+	if req.CreditLimit < 0 {
+		return entity.Customer{}, InvalidInput("Credit limit must be >= 0")
+	}
+
+	phone := normalizePhone(req.Phone)
+	if len(phone) > 20 {
+		return entity.Customer{}, InvalidInput("Phone is too long")
+	}
+
+	return entity.Customer{
+		Name:            name,
+		Phone:           phone,
+		Email:           strings.ToLower(strings.TrimSpace(req.Email)),
+		Address:         strings.TrimSpace(req.Address),
+		Note:            strings.TrimSpace(req.Note),
+		CreditLimit:     req.CreditLimit,
+		CustomerGroupID: req.CustomerGroupID,
+	}, nil
+}
+
+func (s *customerService) checkGroup(ctx context.Context, groupID *uint) error {
+	if groupID == nil {
+		return nil
+	}
+
+	if _, err := s.groupRepo.FindByID(ctx, *groupID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return NotFound("Customer group not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// normalizePhone buang spasi, strip & titik supaya "0812-3456 789" dan "08123456789" dianggap sama.
+func normalizePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+}
+
+func toCustomerDTO(c entity.Customer) dto.CustomerResponse {
+	return dto.CustomerResponse{
+		ID:              c.ID,
+		Name:            c.Name,
+		Phone:           c.Phone,
+		Email:           c.Email,
+		Address:         c.Address,
+		Note:            c.Note,
+		PointsBalance:   c.PointsBalance,
+		CreditLimit:     c.CreditLimit,
+		CreditBalance:   c.CreditBalance,
+		CustomerGroupID: c.CustomerGroupID,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	InvoiceScopeStore    = "store"
+	InvoiceScopeTerminal = "terminal"
+
+	DefaultInvoiceFormat = "INV/{YYYYMMDD}/{SEQ:4}"
+)
+
+var invoiceSeqToken = regexp.MustCompile(`\{SEQ(?::(\d+))?\}`)
+
+// InvoiceConfig format nomor invoice. Token yang didukung: {YYYY} {YY} {MM} {DD} {YYYYMMDD}
+// {STORE} {TERMINAL} dan {SEQ} / {SEQ:n} (counter harian, di-pad n digit).
+type InvoiceConfig struct {
+	Format string
+	// Scope "store" = satu counter per hari untuk seluruh toko, "terminal" = counter per terminal per hari.
+	Scope     string
+	StoreCode string
+	Location  *time.Location
+}
+
+// Validate cek format sekali waktu startup supaya salah config tidak baru ketahuan saat checkout.
+func (c InvoiceConfig) Validate() error {
+	if len(invoiceSeqToken.FindAllString(c.Format, -1)) != 1 {
+		return fmt.Errorf("invoice format %q must contain exactly one {SEQ} token", c.Format)
+	}
+
+	// counter reset tiap hari & invoice_number unique, jadi format wajib memuat tanggal lengkap
+	if !hasDailyDate(c.Format) {
+		return fmt.Errorf("invoice format %q must contain {YYYYMMDD} or {YYYY}/{YY} with {MM} and {DD}", c.Format)
+	}
+
+	if c.Scope != InvoiceScopeStore && c.Scope != InvoiceScopeTerminal {
+		return fmt.Errorf("invoice scope %q must be %q or %q", c.Scope, InvoiceScopeStore, InvoiceScopeTerminal)
+	}
+
+	if c.Scope == InvoiceScopeTerminal && !strings.Contains(c.Format, "{TERMINAL}") {
+		return fmt.Errorf("invoice format %q must contain {TERMINAL} when scope is terminal", c.Format)
+	}
+
+	return nil
+}
+
+func hasDailyDate(format string) bool {
+	if strings.Contains(format, "{YYYYMMDD}") {
+		return true
+	}
+
+	hasYear := strings.Contains(format, "{YYYY}") || strings.Contains(format, "{YY}")
+	return hasYear && strings.Contains(format, "{MM}") && strings.Contains(format, "{DD}")
+}
+
+// counterScope key counter di tabel invoice_counter.
+func (c InvoiceConfig) counterScope(terminalID string) string {
+	if c.Scope == InvoiceScopeTerminal {
+		return InvoiceScopeTerminal + ":" + terminalID
+	}
+	return InvoiceScopeStore
+}
+
+// period tanggal lokal toko, dipakai untuk reset counter harian.
+func (c InvoiceConfig) period(now time.Time) time.Time {
+	if c.Location != nil {
+		now = now.In(c.Location)
+	}
+	return now
+}
+
+func (c InvoiceConfig) render(day time.Time, terminalID string, seq int) string {
+	out := strings.NewReplacer(
+		"{YYYYMMDD}", day.Format("20060102"),
+		"{YYYY}", day.Format("2006"),
+		"{YY}", day.Format("06"),
+		"{MM}", day.Format("01"),
+		"{DD}", day.Format("02"),
+		"{STORE}", c.StoreCode,
+		"{TERMINAL}", terminalID,
+	).Replace(c.Format)
+
+	return invoiceSeqToken.ReplaceAllStringFunc(out, func(tok string) string {
+		width := 0
+		if m := invoiceSeqToken.FindStringSubmatch(tok); m[1] != "" {
+			width, _ = strconv.Atoi(m[1])
+		}
+		return fmt.Sprintf("%0*d", width, seq)
+	})
+}
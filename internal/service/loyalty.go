@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+)
+
+// LoyaltyConfig aturan poin loyalty dari config. AmountPerPoint 0 berarti tidak ada earn,
+// PointValue 0 berarti poin tidak bisa ditukar.
+type LoyaltyConfig struct {
+	// AmountPerPoint nominal belanja untuk 1 poin, misal 10000 = 1 poin per Rp10.000.
+	AmountPerPoint int
+	// PointValue nilai rupiah 1 poin saat ditukar di checkout.
+	PointValue int
+}
+
+// earn poin untuk nominal yang dibayar dengan uang (pembayaran pakai poin tidak dapat poin lagi).
+func (c LoyaltyConfig) earn(amount int) int {
+	if c.AmountPerPoint <= 0 || amount <= 0 {
+		return 0
+	}
+	return amount / c.AmountPerPoint
+}
+
+// postLoyalty catat mutasi poin transaksi ke ledger. Points 0 diabaikan.
+func postLoyalty(ctx context.Context, loyaltyRepo repository.LoyaltyRepository, trx entity.Transaction, kind string, points int, note string) error {
+	if trx.CustomerID == nil || points == 0 {
+		return nil
+	}
+
+	_, err := loyaltyRepo.Post(ctx, entity.LoyaltyLedger{
+		CustomerID:    *trx.CustomerID,
+		TransactionID: &trx.ID,
+		Type:          kind,
+		Points:        points,
+		Note:          note,
+	})
+	if errors.Is(err, repository.ErrInsufficientPoints) {
+		return BadRequest("Points not enough")
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return NotFound("Customer not found")
+	}
+	return err
+}
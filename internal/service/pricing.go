@@ -0,0 +1,401 @@
+package service
+
+import (
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"math"
+	"strings"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// cartLine satu baris keranjang beserta hasil perhitungan harganya.
+type cartLine struct {
+	Product       dto.ProductDetailResponse
+	Quantity      int
+	BasePrice     int        // harga normal product
+	UnitPrice     int        // harga setelah price list grup customer / harga grosir
+	PriceList     *priceList // nil kalau harga normal
+	Gross         int        // UnitPrice * Quantity
+	Promotion     int        // potongan promo otomatis
+	PromotionName string     // nama promo yang kena, dipisah koma kalau lebih dari satu
+	LineDiscount  int        // diskon manual per baris
+	Voucher       int        // bagian potongan voucher yang dialokasikan ke baris ini
+	CartDiscount  int        // bagian diskon keranjang yang dialokasikan ke baris ini
+
+	ServiceCharge int
+	Tax           taxRule
+	TaxBase       int
+	TaxAmount     int
+}
+
+// Net nilai baris setelah semua diskon.
+func (l cartLine) Net() int {
+	return l.Gross - l.Promotion - l.LineDiscount - l.Voucher - l.CartDiscount
+}
+
+// Total yang dibayar customer untuk baris ini (pajak inclusive sudah ada di dalam harga).
+func (l cartLine) Total() int {
+	total := l.Net() + l.ServiceCharge
+	if !l.Tax.Inclusive {
+		total += l.TaxAmount
+	}
+	return total
+}
+
+type taxRule struct {
+	Name      string
+	Rate      float64
+	Inclusive bool
+}
+
+type cartPricing struct {
+	Lines         []cartLine
+	Subtotal      int // total gross sebelum diskon
+	Promotion     int
+	LineDiscount  int
+	VoucherAmount int
+	Voucher       *entity.Voucher // voucher yang dipakai, nil kalau tanpa voucher
+	CartDiscount  int
+	ServiceCharge int
+	TaxBase       int
+	TaxAmount     int
+	Total         int // grand total sebelum pembulatan cash
+}
+
+type pricingInput struct {
+	Items             []dto.CheckoutItem
+	Products          map[uint]dto.ProductDetailResponse
+	CartDiscount      *dto.Discount
+	TaxRates          []entity.TaxRate
+	ServiceChargeRate float64
+	// PriceList harga grup customer, nil kalau tanpa customer atau customer tanpa grup.
+	PriceList *priceList
+	// Tiers harga grosir per product, urut min quantity.
+	Tiers map[uint][]entity.ProductPriceTier
+	// Promotions promo yang berlaku saat ini, urut priority tertinggi dulu.
+	Promotions []entity.Promotion
+	// Voucher sudah dicek aktif & kuotanya, nil kalau checkout tanpa voucher.
+	Voucher *entity.Voucher
+}
+
+// priceList harga khusus satu customer group.
+type priceList struct {
+	ID    uint
+	Name  string
+	Rules []entity.GroupPrice
+}
+
+func (p cartPricing) DiscountTotal() int {
+	return p.Promotion + p.LineDiscount + p.VoucherAmount + p.CartDiscount
+}
+
+// priceCart hitung harga semua baris keranjang. Dipakai checkout supaya aturan harga ada di satu tempat.
+// Urutan: harga dasar (price list grup / grosir, ambil yang termurah) -> promo -> diskon baris -> voucher -> diskon keranjang -> service charge -> pajak.
+func priceCart(in pricingInput) (cartPricing, error) {
+	var res cartPricing
+
+	for _, item := range in.Items {
+		p := in.Products[item.ProductID]
+
+		line := cartLine{
+			Product:   p,
+			Quantity:  item.Quantity,
+			BasePrice: p.Price,
+			UnitPrice: p.Price,
+		}
+		if price, ok := in.PriceList.resolve(p); ok {
+			line.UnitPrice = price
+			line.PriceList = in.PriceList
+		}
+		// harga grosir hanya dipakai kalau lebih murah dari harga grup
+		if price, ok := tierPrice(in.Tiers[p.ID], item.Quantity); ok && price < line.UnitPrice {
+			line.UnitPrice = price
+			line.PriceList = nil
+		}
+		line.Gross = line.UnitPrice * line.Quantity
+
+		res.Lines = append(res.Lines, line)
+	}
+
+	applyPromotions(res.Lines, in.Promotions)
+
+	// diskon manual dihitung dari nilai setelah promo
+	for i, item := range in.Items {
+		line := &res.Lines[i]
+		discount, err := discountAmount(item.Discount, line.Gross-line.Promotion)
+		if err != nil {
+			return cartPricing{}, err
+		}
+		line.LineDiscount = discount
+
+		res.Subtotal += line.Gross
+		res.Promotion += line.Promotion
+		res.LineDiscount += line.LineDiscount
+	}
+
+	if in.Voucher != nil {
+		if err := applyVoucher(&res, *in.Voucher); err != nil {
+			return cartPricing{}, err
+		}
+	}
+
+	afterLine := res.Subtotal - res.Promotion - res.LineDiscount - res.VoucherAmount
+	discount, err := discountAmount(in.CartDiscount, afterLine)
+	if err != nil {
+		return cartPricing{}, err
+	}
+	res.CartDiscount = discount
+
+	// diskon keranjang dibagi proporsional ke baris, dipakai untuk hitung refund retur
+	weights := make([]int, len(res.Lines))
+	for i, l := range res.Lines {
+		weights[i] = l.Gross - l.Promotion - l.LineDiscount - l.Voucher
+	}
+	for i, share := range allocate(res.CartDiscount, weights) {
+		res.Lines[i].CartDiscount = share
+	}
+
+	// service charge dari nilai setelah diskon, dibagi ke baris supaya ikut kena pajak
+	netTotal := afterLine - res.CartDiscount
+	res.ServiceCharge = percentOf(netTotal, in.ServiceChargeRate)
+	for i := range weights {
+		weights[i] = res.Lines[i].Net()
+	}
+	for i, share := range allocate(res.ServiceCharge, weights) {
+		res.Lines[i].ServiceCharge = share
+	}
+
+	for i := range res.Lines {
+		line := &res.Lines[i]
+		line.Tax = resolveTax(in.TaxRates, line.Product)
+
+		base := line.Net() + line.ServiceCharge
+		if line.Tax.Inclusive {
+			line.TaxAmount = int(math.Round(float64(base) * line.Tax.Rate / (100 + line.Tax.Rate)))
+			line.TaxBase = base - line.TaxAmount
+		} else {
+			line.TaxAmount = percentOf(base, line.Tax.Rate)
+			line.TaxBase = base
+		}
+
+		res.TaxBase += line.TaxBase
+		res.TaxAmount += line.TaxAmount
+		res.Total += line.Total()
+	}
+
+	return res, nil
+}
+
+// applyVoucher potong voucher dari baris yang category-nya berlaku, dibagi proporsional ke baris itu.
+// Minimum belanja dihitung dari nilai baris yang berlaku setelah promo & diskon baris.
+func applyVoucher(res *cartPricing, v entity.Voucher) error {
+	allowed := make(map[uint]bool, len(v.CategoryIDs))
+	for _, id := range v.CategoryIDs {
+		allowed[id] = true
+	}
+
+	weights := make([]int, len(res.Lines))
+	base := 0
+	for i, l := range res.Lines {
+		if len(allowed) > 0 && !allowed[l.Product.CategoryID] {
+			continue
+		}
+		weights[i] = l.Gross - l.Promotion - l.LineDiscount
+		base += weights[i]
+	}
+
+	if base <= 0 {
+		return BadRequest("Voucher is not applicable to these items")
+	}
+	if base < v.MinSpend {
+		return BadRequest("Minimum spend for voucher not reached")
+	}
+
+	var amount int
+	switch v.Type {
+	case entity.VoucherFixed:
+		amount = min(int(math.Round(v.Value)), base)
+	case entity.VoucherPercent:
+		amount = percentOf(base, v.Value)
+		if v.MaxDiscount > 0 {
+			amount = min(amount, v.MaxDiscount)
+		}
+	}
+
+	res.VoucherAmount = amount
+	res.Voucher = &v
+	for i, share := range allocate(amount, weights) {
+		res.Lines[i].Voucher = share
+	}
+	return nil
+}
+
+// resolveTax pilih tarif paling spesifik: product > category > default. Tanpa tarif berarti pajak 0.
+func resolveTax(rates []entity.TaxRate, p dto.ProductDetailResponse) taxRule {
+	var byProduct, byCategory, byDefault *entity.TaxRate
+	for i := range rates {
+		t := &rates[i]
+		switch {
+		case t.ProductID != nil:
+			if *t.ProductID == p.ID {
+				byProduct = t
+			}
+		case t.CategoryID != nil:
+			if *t.CategoryID == p.CategoryID {
+				byCategory = t
+			}
+		default:
+			byDefault = t
+		}
+	}
+
+	for _, t := range []*entity.TaxRate{byProduct, byCategory, byDefault} {
+		if t != nil {
+			return taxRule{Name: t.Name, Rate: t.Rate, Inclusive: t.Inclusive}
+		}
+	}
+
+	return taxRule{}
+}
+
+// resolve harga product di price list: aturan product > category. ok false berarti pakai harga normal.
+func (l *priceList) resolve(p dto.ProductDetailResponse) (int, bool) {
+	if l == nil {
+		return 0, false
+	}
+
+	var byProduct, byCategory *entity.GroupPrice
+	for i := range l.Rules {
+		r := &l.Rules[i]
+		switch {
+		case r.ProductID != nil && *r.ProductID == p.ID:
+			byProduct = r
+		case r.CategoryID != nil && *r.CategoryID == p.CategoryID:
+			byCategory = r
+		}
+	}
+
+	rule := byProduct
+	if rule == nil {
+		rule = byCategory
+	}
+	if rule == nil {
+		return 0, false
+	}
+
+	if rule.Type == entity.GroupPriceFixed {
+		return int(math.Round(rule.Value)), true
+	}
+	return p.Price - percentOf(p.Price, rule.Value), true
+}
+
+// tierPrice harga tier dengan min quantity terbesar yang masih <= qty. tiers harus urut min quantity.
+func tierPrice(tiers []entity.ProductPriceTier, qty int) (int, bool) {
+	price, ok := 0, false
+	for _, t := range tiers {
+		if t.MinQuantity > qty {
+			break
+		}
+		price, ok = t.Price, true
+	}
+	return price, ok
+}
+
+func (l *priceList) id() *uint {
+	if l == nil {
+		return nil
+	}
+	return &l.ID
+}
+
+func (l *priceList) name() string {
+	if l == nil {
+		return ""
+	}
+	return l.Name
+}
+
+func percentOf(amount int, rate float64) int {
+	if rate <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(amount) * rate / 100))
+}
+
+// discountAmount nominal diskon untuk base, ditolak kalau membuat nilai di bawah nol.
+func discountAmount(d *dto.Discount, base int) (int, error) {
+	if d == nil {
+		return 0, nil
+	}
+
+	if d.Value <= 0 {
+		return 0, InvalidInput("Discount value must be > 0")
+	}
+
+	switch normalizeDiscountType(d.Type) {
+	case DiscountPercent:
+		if d.Value > 100 {
+			return 0, InvalidInput("Discount percent must be <= 100")
+		}
+		return percentOf(base, d.Value), nil
+	case DiscountFixed:
+		amount := int(math.Round(d.Value))
+		if amount > base {
+			return 0, InvalidInput("Discount exceeds price")
+		}
+		return amount, nil
+	default:
+		return 0, InvalidInput("Invalid discount type")
+	}
+}
+
+// normalizeDiscountType type diskon tidak case-sensitive, "Fixed " sama dengan "fixed".
+func normalizeDiscountType(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// allocate bagi amount ke beberapa baris sebanding weights (largest remainder),
+// jumlah hasilnya selalu tepat sama dengan amount.
+func allocate(amount int, weights []int) []int {
+	shares := make([]int, len(weights))
+
+	var totalWeight int
+	for _, w := range weights {
+		totalWeight += w
+	}
+	if amount == 0 || totalWeight <= 0 {
+		return shares
+	}
+
+	type remainder struct {
+		idx int
+		rem int
+	}
+
+	rest := amount
+	rems := make([]remainder, 0, len(weights))
+	for i, w := range weights {
+		shares[i] = amount * w / totalWeight
+		rest -= shares[i]
+		rems = append(rems, remainder{idx: i, rem: amount * w % totalWeight})
+	}
+
+	// sisa pembulatan ke baris dengan sisa bagi terbesar
+	for rest > 0 {
+		best := 0
+		for i := range rems {
+			if rems[i].rem > rems[best].rem {
+				best = i
+			}
+		}
+		shares[rems[best].idx]++
+		rems[best].rem = -1
+		rest--
+	}
+
+	return shares
+}
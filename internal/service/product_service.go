@@ -7,6 +7,7 @@ import (
 	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
 	"kasir-api/internal/repository"
+	"sort"
 
 	"go.uber.org/zap"
 )
@@ -21,12 +22,13 @@ type ProductService interface {
 }
 
 type productService struct {
+	txManager    repository.TxManager
 	productRepo  repository.ProductRepository
 	categoryRepo repository.CategoryRepository
 }
 
-func NewProductService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository) ProductService {
-	return &productService{productRepo: productRepo, categoryRepo: categoryRepo}
+func NewProductService(txManager repository.TxManager, productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository) ProductService {
+	return &productService{txManager: txManager, productRepo: productRepo, categoryRepo: categoryRepo}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req dto.Product) (dto.ProductResponse, error) {
@@ -62,6 +64,12 @@ func (s *productService) CreateProduct(ctx context.Context, req dto.Product) (dt
 		}
 	}
 
+	tiers, err := validatePriceTiers(req.Tiers, req.Price)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_tiers"), zap.Error(err))
+		return dto.ProductResponse{}, err
+	}
+
 	p := entity.Product{
 		CategoryID: catID,
 		Name:       req.Name,
@@ -69,7 +77,17 @@ func (s *productService) CreateProduct(ctx context.Context, req dto.Product) (dt
 		Stock:      req.Stock,
 	}
 
-	created, err := s.productRepo.Create(ctx, p)
+	var created entity.Product
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.productRepo.Create(ctx, p)
+		if err != nil {
+			return err
+		}
+
+		tiers, err = s.productRepo.ReplacePriceTiers(ctx, created.ID, tiers)
+		return err
+	})
 	if err != nil {
 		log.Error("out", zap.Error(err))
 		return dto.ProductResponse{}, err
@@ -81,6 +99,7 @@ func (s *productService) CreateProduct(ctx context.Context, req dto.Product) (dt
 		Name:       created.Name,
 		Price:      created.Price,
 		Stock:      created.Stock,
+		Tiers:      toPriceTierDTO(tiers),
 		CreatedAt:  created.CreatedAt,
 		UpdatedAt:  created.UpdatedAt,
 	}
@@ -108,12 +127,19 @@ func (s *productService) GetProductByID(ctx context.Context, id uint) (dto.Produ
 		return dto.ProductResponse{}, err
 	}
 
+	tiers, err := s.productRepo.FindPriceTiers(ctx, []uint{p.ID})
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.ProductResponse{}, err
+	}
+
 	res := dto.ProductResponse{
 		ID:         p.ID,
 		CategoryID: p.CategoryID,
 		Name:       p.Name,
 		Price:      p.Price,
 		Stock:      p.Stock,
+		Tiers:      toPriceTierDTO(tiers),
 		CreatedAt:  p.CreatedAt,
 		UpdatedAt:  p.UpdatedAt,
 	}
@@ -137,6 +163,22 @@ func (s *productService) GetAllProduct(ctx context.Context) ([]dto.ProductRespon
 		return nil, err
 	}
 
+	ids := make([]uint, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.ID)
+	}
+
+	tiers, err := s.productRepo.FindPriceTiers(ctx, ids)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	tiersByProduct := make(map[uint][]entity.ProductPriceTier, len(products))
+	for _, t := range tiers {
+		tiersByProduct[t.ProductID] = append(tiersByProduct[t.ProductID], t)
+	}
+
 	res := make([]dto.ProductResponse, 0, len(products))
 	for _, p := range products {
 		res = append(res, dto.ProductResponse{
@@ -145,6 +187,7 @@ func (s *productService) GetAllProduct(ctx context.Context) ([]dto.ProductRespon
 			Name:       p.Name,
 			Price:      p.Price,
 			Stock:      p.Stock,
+			Tiers:      toPriceTierDTO(tiersByProduct[p.ID]),
 			CreatedAt:  p.CreatedAt,
 			UpdatedAt:  p.UpdatedAt,
 		})
@@ -164,7 +207,7 @@ func (s *productService) UpdateProductByID(ctx context.Context, id uint, req dto
 	log.Info("in")
 
 	// minimal 1 field
-	if req.CategoryID == nil && req.Name == nil && req.Price == nil && req.Stock == nil {
+	if req.CategoryID == nil && req.Name == nil && req.Price == nil && req.Stock == nil && req.Tiers == nil {
 		log.Warn("out", zap.String("result", "no_fields_to_update"))
 		return dto.ProductResponse{}, InvalidInput("Nothing to update")
 	}
@@ -208,7 +251,39 @@ func (s *productService) UpdateProductByID(ctx context.Context, id uint, req dto
 		update.Stock = *req.Stock
 	}
 
-	updated, err := s.productRepo.Update(ctx, update)
+	// tier dicek terhadap harga setelah update, jadi product & tier disimpan dalam satu transaction
+	var updated entity.Product
+	var tiers []entity.ProductPriceTier
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if req.CategoryID != nil || req.Name != nil || req.Price != nil || req.Stock != nil {
+			updated, err = s.productRepo.Update(ctx, update)
+		} else {
+			updated, err = s.productRepo.FindByID(ctx, id)
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Tiers == nil {
+			tiers, err = s.productRepo.FindPriceTiers(ctx, []uint{id})
+			if err != nil {
+				return err
+			}
+			if _, err := validatePriceTiers(toPriceTierDTO(tiers), updated.Price); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		tiers, err = validatePriceTiers(*req.Tiers, updated.Price)
+		if err != nil {
+			return err
+		}
+
+		tiers, err = s.productRepo.ReplacePriceTiers(ctx, id, tiers)
+		return err
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			log.Warn("out", zap.String("result", "not_found"))
@@ -224,6 +299,7 @@ func (s *productService) UpdateProductByID(ctx context.Context, id uint, req dto
 		Name:       updated.Name,
 		Price:      updated.Price,
 		Stock:      updated.Stock,
+		Tiers:      toPriceTierDTO(tiers),
 		CreatedAt:  updated.CreatedAt,
 		UpdatedAt:  updated.UpdatedAt,
 	}
@@ -284,3 +360,38 @@ func (s *productService) GetProductDetailByID(ctx context.Context, id uint) (dto
 
 	return res, nil
 }
+
+// validatePriceTiers cek tier grosir terhadap harga normal: min quantity >= 2 dan unik,
+// harga di bawah harga normal dan tidak naik saat quantity makin besar.
+func validatePriceTiers(req []dto.PriceTier, basePrice int) ([]entity.ProductPriceTier, error) {
+	tiers := make([]entity.ProductPriceTier, 0, len(req))
+	for _, t := range req {
+		if t.MinQuantity < 2 {
+			return nil, InvalidInput("Tier min quantity must be >= 2")
+		}
+		if t.Price <= 0 || t.Price >= basePrice {
+			return nil, InvalidInput("Tier price must be greater than 0 and lower than product price")
+		}
+		tiers = append(tiers, entity.ProductPriceTier{MinQuantity: t.MinQuantity, Price: t.Price})
+	}
+
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinQuantity < tiers[j].MinQuantity })
+	for i := 1; i < len(tiers); i++ {
+		if tiers[i].MinQuantity == tiers[i-1].MinQuantity {
+			return nil, InvalidInput("Duplicate tier min quantity")
+		}
+		if tiers[i].Price > tiers[i-1].Price {
+			return nil, InvalidInput("Tier price must not increase with quantity")
+		}
+	}
+
+	return tiers, nil
+}
+
+func toPriceTierDTO(tiers []entity.ProductPriceTier) []dto.PriceTier {
+	res := make([]dto.PriceTier, 0, len(tiers))
+	for _, t := range tiers {
+		res = append(res, dto.PriceTier{MinQuantity: t.MinQuantity, Price: t.Price})
+	}
+	return res
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"fmt"
+	"kasir-api/internal/entity"
+	"sort"
+	"strings"
+	"time"
+)
+
+// applyPromotions hitung potongan promo ke tiap baris. promos harus sudah urut priority tertinggi dulu.
+// Promo non-stackable hanya kena ke baris yang belum dapat promo apapun dan mengunci baris itu;
+// promo stackable boleh menumpuk dengan promo stackable lain.
+func applyPromotions(lines []cartLine, promos []entity.Promotion) {
+	type lineState struct {
+		locked  bool
+		applied int
+	}
+	state := make([]lineState, len(lines))
+
+	for _, p := range promos {
+		var idx []int
+		for i, l := range lines {
+			if !promoTargets(p, l) || state[i].locked || l.Gross-l.Promotion <= 0 {
+				continue
+			}
+			if !p.Stackable && state[i].applied > 0 {
+				continue
+			}
+			idx = append(idx, i)
+		}
+		if len(idx) == 0 {
+			continue
+		}
+
+		for i, amount := range promoDiscounts(p, lines, idx) {
+			amount = min(amount, lines[i].Gross-lines[i].Promotion)
+			if amount <= 0 {
+				continue
+			}
+
+			l := &lines[i]
+			l.Promotion += amount
+			if l.PromotionName == "" {
+				l.PromotionName = p.Name
+			} else {
+				l.PromotionName += ", " + p.Name
+			}
+
+			state[i].applied++
+			if !p.Stackable {
+				state[i].locked = true
+			}
+		}
+	}
+}
+
+func promoTargets(p entity.Promotion, l cartLine) bool {
+	switch {
+	case p.ProductID != nil:
+		return *p.ProductID == l.Product.ID
+	case p.CategoryID != nil:
+		return *p.CategoryID == l.Product.CategoryID
+	default:
+		return false
+	}
+}
+
+// promoDiscounts potongan promo per index baris. Untuk buy_x_get_y & bundle_price semua unit dari
+// baris yang kena digabung (boleh beda product dalam satu category), urut harga termahal dulu,
+// lalu dipotong per kelompok; unit sisa yang tidak genap satu kelompok bayar harga biasa.
+// Unit tidak dijabarkan satu-satu: tiap baris jadi satu run posisi [start, end) dan kelompok
+// dihitung dari quantity, jadi biayanya ikut jumlah baris, bukan jumlah unit.
+func promoDiscounts(p entity.Promotion, lines []cartLine, idx []int) map[int]int {
+	out := make(map[int]int, len(idx))
+
+	if p.Type == entity.PromoPercentOff {
+		for _, i := range idx {
+			out[i] = percentOf(lines[i].Gross-lines[i].Promotion, p.Percent)
+		}
+		return out
+	}
+
+	order := append([]int(nil), idx...)
+	sort.SliceStable(order, func(a, b int) bool { return lines[order[a]].UnitPrice > lines[order[b]].UnitPrice })
+
+	type run struct {
+		line       int
+		price      int
+		start, end int
+	}
+	runs := make([]run, 0, len(order))
+	total := 0
+	for _, i := range order {
+		if lines[i].Quantity <= 0 {
+			continue
+		}
+		runs = append(runs, run{line: i, price: lines[i].UnitPrice, start: total, end: total + lines[i].Quantity})
+		total += lines[i].Quantity
+	}
+
+	switch p.Type {
+	case entity.PromoBuyXGetY:
+		size := p.BuyQuantity + p.GetQuantity
+		limit := total / size * size
+		// free(n) = jumlah unit gratis di n posisi pertama; di tiap kelompok, Y unit termurah yang gratis
+		free := func(n int) int {
+			n = min(n, limit)
+			return n/size*p.GetQuantity + max(0, n%size-p.BuyQuantity)
+		}
+		for _, r := range runs {
+			out[r.line] += (free(r.end) - free(r.start)) * r.price
+		}
+
+	case entity.PromoBundlePrice:
+		size := p.BuyQuantity
+		limit := total / size * size
+		k := 0
+		for pos := 0; pos < limit; {
+			for runs[k].end <= pos {
+				k++
+			}
+			r := runs[k]
+
+			// kelompok utuh di dalam satu run: potongannya sama untuk tiap kelompok
+			if n := (min(r.end, limit) - pos) / size; n > 0 {
+				out[r.line] += n * max(0, size*r.price-p.BundlePrice)
+				pos += n * size
+				continue
+			}
+
+			// kelompok yang melintasi beberapa run dibagi proporsional harga tiap potongan run
+			var (
+				members []int
+				weights []int
+				sum     int
+			)
+			for j, end := k, pos+size; pos < end; j++ {
+				n := min(runs[j].end, end) - pos
+				members = append(members, runs[j].line)
+				weights = append(weights, n*runs[j].price)
+				sum += n * runs[j].price
+				pos += n
+			}
+			if sum <= p.BundlePrice {
+				continue
+			}
+			for m, share := range allocate(sum-p.BundlePrice, weights) {
+				out[members[m]] += share
+			}
+		}
+	}
+
+	return out
+}
+
+// activePromotions saring promo yang jam berlaku hariannya mencakup now (sudah di timezone toko).
+func activePromotions(promos []entity.Promotion, now time.Time) []entity.Promotion {
+	out := make([]entity.Promotion, 0, len(promos))
+	for _, p := range promos {
+		if promoInDailyWindow(p, now) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func promoInDailyWindow(p entity.Promotion, now time.Time) bool {
+	if p.StartTime == "" && p.EndTime == "" {
+		return true
+	}
+
+	start, err := parseClock(p.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(p.EndTime)
+	if err != nil {
+		return false
+	}
+
+	cur := now.Hour()*60 + now.Minute()
+	if start <= end {
+		return cur >= start && cur < end
+	}
+	// melewati tengah malam, mis. 22:00-02:00
+	return cur >= start || cur < end
+}
+
+// parseClock "HH:MM" jadi menit sejak 00:00.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %w", s, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
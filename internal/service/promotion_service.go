@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req dto.Promotion) (dto.PromotionResponse, error)
+	GetPromotionByID(ctx context.Context, id uint) (dto.PromotionResponse, error)
+	GetAllPromotion(ctx context.Context) ([]dto.PromotionResponse, error)
+	UpdatePromotionByID(ctx context.Context, id uint, req dto.Promotion) (dto.PromotionResponse, error)
+	DeletePromotionByID(ctx context.Context, id uint) error
+}
+
+type promotionService struct {
+	promoRepo    repository.PromotionRepository
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewPromotionService(promoRepo repository.PromotionRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository) PromotionService {
+	return &promotionService{promoRepo: promoRepo, categoryRepo: categoryRepo, productRepo: productRepo}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req dto.Promotion) (dto.PromotionResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "PromotionService.CreatePromotion"),
+	)
+
+	log.Info("in")
+
+	p, err := s.validatePromotion(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.PromotionResponse{}, err
+	}
+
+	created, err := s.promoRepo.Create(ctx, p)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.PromotionResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("promotion_id", created.ID))
+
+	return toPromotionDTO(created), nil
+}
+
+func (s *promotionService) GetPromotionByID(ctx context.Context, id uint) (dto.PromotionResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "PromotionService.GetPromotionByID"),
+	)
+
+	log.Info("in")
+
+	p, err := s.promoRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.PromotionResponse{}, NotFound("Promotion not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.PromotionResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toPromotionDTO(p), nil
+}
+
+func (s *promotionService) GetAllPromotion(ctx context.Context) ([]dto.PromotionResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "PromotionService.GetAllPromotion"),
+	)
+
+	log.Info("in")
+
+	promos, err := s.promoRepo.FindAll(ctx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]dto.PromotionResponse, 0, len(promos))
+	for _, p := range promos {
+		res = append(res, toPromotionDTO(p))
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func (s *promotionService) UpdatePromotionByID(ctx context.Context, id uint, req dto.Promotion) (dto.PromotionResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "PromotionService.UpdatePromotionByID"),
+	)
+
+	log.Info("in")
+
+	p, err := s.validatePromotion(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.PromotionResponse{}, err
+	}
+	p.ID = id
+
+	updated, err := s.promoRepo.Update(ctx, p)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.PromotionResponse{}, NotFound("Promotion not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.PromotionResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toPromotionDTO(updated), nil
+}
+
+func (s *promotionService) DeletePromotionByID(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "PromotionService.DeletePromotionByID"),
+	)
+
+	log.Info("in")
+
+	if err := s.promoRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Promotion not found")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (s *promotionService) validatePromotion(ctx context.Context, req dto.Promotion) (entity.Promotion, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return entity.Promotion{}, InvalidInput("Name is required")
+	}
+
+	if (req.ProductID == nil) == (req.CategoryID == nil) {
+		return entity.Promotion{}, InvalidInput("Promotion must target either a product or a category")
+	}
+
+	p := entity.Promotion{
+		Name:       name,
+		Type:       strings.ToLower(strings.TrimSpace(req.Type)),
+		ProductID:  req.ProductID,
+		CategoryID: req.CategoryID,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		StartTime:  strings.TrimSpace(req.StartTime),
+		EndTime:    strings.TrimSpace(req.EndTime),
+		Priority:   req.Priority,
+		Stackable:  req.Stackable,
+		Active:     req.Active == nil || *req.Active,
+	}
+
+	// field yang tidak dipakai type ini disimpan 0 supaya tidak membingungkan
+	switch p.Type {
+	case entity.PromoBuyXGetY:
+		if req.BuyQuantity < 1 || req.GetQuantity < 1 {
+			return entity.Promotion{}, InvalidInput("Buy and get quantity must be >= 1")
+		}
+		p.BuyQuantity, p.GetQuantity = req.BuyQuantity, req.GetQuantity
+	case entity.PromoBundlePrice:
+		if req.BuyQuantity < 2 {
+			return entity.Promotion{}, InvalidInput("Bundle quantity must be >= 2")
+		}
+		if req.BundlePrice <= 0 {
+			return entity.Promotion{}, InvalidInput("Bundle price must be greater than 0")
+		}
+		p.BuyQuantity, p.BundlePrice = req.BuyQuantity, req.BundlePrice
+	case entity.PromoPercentOff:
+		if req.Percent <= 0 || req.Percent > 100 {
+			return entity.Promotion{}, InvalidInput("Percent must be between 0 and 100")
+		}
+		p.Percent = req.Percent
+	default:
+		return entity.Promotion{}, InvalidInput("Invalid promotion type")
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.StartsAt.Before(*p.EndsAt) {
+		return entity.Promotion{}, InvalidInput("Promotion must start before it ends")
+	}
+
+	if (p.StartTime == "") != (p.EndTime == "") {
+		return entity.Promotion{}, InvalidInput("Start time and end time must be set together")
+	}
+	if p.StartTime != "" {
+		start, err := parseClock(p.StartTime)
+		if err != nil {
+			return entity.Promotion{}, InvalidInput("Invalid start time, use HH:MM")
+		}
+		end, err := parseClock(p.EndTime)
+		if err != nil {
+			return entity.Promotion{}, InvalidInput("Invalid end time, use HH:MM")
+		}
+		if start == end {
+			return entity.Promotion{}, InvalidInput("Start time and end time must differ")
+		}
+	}
+
+	if req.CategoryID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *req.CategoryID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.Promotion{}, NotFound("Category not found")
+			}
+			return entity.Promotion{}, err
+		}
+	}
+
+	if req.ProductID != nil {
+		if _, err := s.productRepo.FindByID(ctx, *req.ProductID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.Promotion{}, NotFound("Product not found")
+			}
+			return entity.Promotion{}, err
+		}
+	}
+
+	return p, nil
+}
+
+func toPromotionDTO(p entity.Promotion) dto.PromotionResponse {
+	return dto.PromotionResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Type:        p.Type,
+		ProductID:   p.ProductID,
+		CategoryID:  p.CategoryID,
+		BuyQuantity: p.BuyQuantity,
+		GetQuantity: p.GetQuantity,
+		BundlePrice: p.BundlePrice,
+		Percent:     p.Percent,
+		StartsAt:    p.StartsAt,
+		EndsAt:      p.EndsAt,
+		StartTime:   p.StartTime,
+		EndTime:     p.EndTime,
+		Priority:    p.Priority,
+		Stackable:   p.Stackable,
+		Active:      p.Active,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
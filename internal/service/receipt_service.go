@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/receipt"
+	"kasir-api/internal/repository"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type ReceiptService interface {
+	GetReceipt(ctx context.Context, trxID uint, format string, width int) ([]byte, error)
+	GetInvoice(ctx context.Context, trxID uint, format string) ([]byte, error)
+}
+
+type receiptService struct {
+	trxSvc    TrxService
+	storeRepo repository.StoreSettingRepository
+	loc       *time.Location
+}
+
+func NewReceiptService(trxSvc TrxService, storeRepo repository.StoreSettingRepository, loc *time.Location) ReceiptService {
+	return &receiptService{trxSvc: trxSvc, storeRepo: storeRepo, loc: loc}
+}
+
+func (s *receiptService) GetReceipt(ctx context.Context, trxID uint, format string, width int) ([]byte, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ReceiptService.GetReceipt"),
+		zap.Uint("transaction_id", trxID),
+		zap.String("format", format),
+		zap.Int("width", width),
+	)
+
+	log.Info("in")
+
+	if format != receipt.FormatText && format != receipt.FormatESCPOS {
+		log.Warn("out", zap.String("result", "invalid_format"))
+		return nil, InvalidInput("Format must be text or escpos")
+	}
+
+	if _, err := receipt.Columns(width); err != nil {
+		log.Warn("out", zap.String("result", "invalid_width"))
+		return nil, InvalidInput("Width must be 58 or 80")
+	}
+
+	trx, setting, err := s.load(ctx, trxID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "load_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	out, err := receipt.Render(toReceiptStore(setting), trx, receipt.Options{Format: format, Width: width, Location: s.loc})
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, Internal("Failed to render receipt")
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("bytes", len(out)))
+
+	return out, nil
+}
+
+func (s *receiptService) GetInvoice(ctx context.Context, trxID uint, format string) ([]byte, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ReceiptService.GetInvoice"),
+		zap.Uint("transaction_id", trxID),
+		zap.String("format", format),
+	)
+
+	log.Info("in")
+
+	if format != receipt.InvoiceFormatHTML && format != receipt.InvoiceFormatPDF {
+		log.Warn("out", zap.String("result", "invalid_format"))
+		return nil, InvalidInput("Format must be html or pdf")
+	}
+
+	trx, setting, err := s.load(ctx, trxID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "load_failed"), zap.Error(err))
+		return nil, err
+	}
+
+	inv := receipt.NewInvoice(toReceiptStore(setting), trx, s.loc)
+
+	var out []byte
+	if format == receipt.InvoiceFormatPDF {
+		out, err = receipt.RenderInvoicePDF(setting.InvoicePDFTemplate, inv)
+	} else {
+		out, err = receipt.RenderInvoiceHTML(setting.InvoiceTemplate, inv)
+	}
+	if err != nil {
+		log.Error("out", zap.String("result", "render_failed"), zap.Error(err))
+		return nil, Internal("Failed to render invoice")
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("bytes", len(out)))
+
+	return out, nil
+}
+
+func (s *receiptService) load(ctx context.Context, trxID uint) (dto.Transaction, entity.StoreSetting, error) {
+	trx, err := s.trxSvc.GetTransactionByID(ctx, trxID)
+	if err != nil {
+		return dto.Transaction{}, entity.StoreSetting{}, err
+	}
+
+	setting, err := s.storeRepo.Get(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return dto.Transaction{}, entity.StoreSetting{}, NotFound("Store setting not found")
+		}
+		return dto.Transaction{}, entity.StoreSetting{}, err
+	}
+
+	return trx, setting, nil
+}
+
+func toReceiptStore(s entity.StoreSetting) receipt.Store {
+	return receipt.Store{
+		Name:    s.Name,
+		Address: s.Address,
+		Phone:   s.Phone,
+		NPWP:    s.NPWP,
+		Footer:  s.ReceiptFooter,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"kasir-api/internal/delivery/http/middleware"
 	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
 	"kasir-api/internal/repository"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 type ReportService interface {
 	GetReport(ctx context.Context, startDate string, endDate string) (dto.Report, error)
+	GetCreditAging(ctx context.Context, asOf string, customerID uint) (dto.CreditAging, error)
 }
 
 type reportService struct {
@@ -61,6 +63,32 @@ func (s *reportService) GetReport(ctx context.Context, startDate string, endDate
 		}
 	}
 
+	// kembalian dibayar dari cash, jadi cash yang diterima = cash tendered - kembalian
+	payments := make([]dto.PaymentSummary, len(entityRes.Payments))
+	for i, v := range entityRes.Payments {
+		amount := v.Amount
+		if v.Method == entity.PaymentMethodCash {
+			amount -= entityRes.TotalChange
+		}
+
+		payments[i] = dto.PaymentSummary{
+			Method:           v.Method,
+			TransactionCount: v.TransactionCount,
+			Amount:           amount,
+		}
+	}
+
+	taxes := make([]dto.TaxSummary, len(entityRes.Taxes))
+	for i, v := range entityRes.Taxes {
+		taxes[i] = dto.TaxSummary{
+			Name:      v.Name,
+			Rate:      v.Rate,
+			Inclusive: v.Inclusive,
+			TaxBase:   v.TaxBase,
+			TaxAmount: v.TaxAmount,
+		}
+	}
+
 	var rangeStr string
 	if startDate == "" {
 		rangeStr = nowStr
@@ -72,12 +100,114 @@ func (s *reportService) GetReport(ctx context.Context, startDate string, endDate
 
 	res := dto.Report{
 		ReportRange:      rangeStr,
+		GrossRevenue:     entityRes.GrossRevenue,
+		TotalDiscount:    entityRes.TotalDiscount,
 		TotalRevenue:     entityRes.TotalRevenue,
+		TotalReturn:      entityRes.TotalReturn,
+		NetRevenue:       entityRes.TotalRevenue - entityRes.TotalReturn,
+		TotalRounding:    entityRes.TotalRounding,
+		TotalService:     entityRes.TotalService,
+		TotalTax:         entityRes.TotalTax,
+		TotalCashIn:      entityRes.TotalCashIn,
+		TotalCashOut:     entityRes.TotalCashOut,
+		Taxes:            taxes,
 		TotalTransaction: entityRes.TotalTransaction,
 		BestProduct:      bestProducts,
+		Payments:         payments,
 	}
 
 	log.Info("out", zap.String("result", "ok"))
 
 	return res, nil
 }
+
+// GetCreditAging umur piutang per tanggal asOf. Pelunasan dialokasikan FIFO ke transaksi credit
+// paling lama, sisa yang belum lunas dikelompokkan berdasarkan umur transaksinya.
+func (s *reportService) GetCreditAging(ctx context.Context, asOf string, customerID uint) (dto.CreditAging, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ReportService.GetCreditAging"),
+	)
+
+	log.Info("in")
+
+	loc := time.FixedZone("WIB", 7*3600)
+	if asOf == "" {
+		asOf = time.Now().In(loc).Format("2006-01-02")
+	}
+
+	asOfDate, err := time.ParseInLocation("2006-01-02", asOf, loc)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_as_of"))
+		return dto.CreditAging{}, InvalidInput("Invalid input date")
+	}
+
+	sales, err := s.reportRepo.FindCreditSales(ctx, customerID, asOf)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CreditAging{}, err
+	}
+
+	repaid, err := s.reportRepo.SumCreditRepayments(ctx, customerID, asOf)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.CreditAging{}, err
+	}
+
+	remaining := make(map[uint]int, len(repaid))
+	for _, r := range repaid {
+		remaining[r.CustomerID] = r.Amount
+	}
+
+	res := dto.CreditAging{AsOf: asOf, Customers: []dto.CustomerAging{}}
+	var cur *dto.CustomerAging
+	for _, sale := range sales {
+		// sales sudah urut per customer lalu dari transaksi terlama
+		if cur == nil || cur.CustomerID != sale.CustomerID {
+			if cur != nil && cur.Total > 0 {
+				res.Customers = append(res.Customers, *cur)
+			}
+			cur = &dto.CustomerAging{CustomerID: sale.CustomerID, CustomerName: sale.CustomerName, Invoices: []dto.AgingInvoice{}}
+		}
+
+		applied := min(remaining[sale.CustomerID], sale.Amount)
+		remaining[sale.CustomerID] -= applied
+		outstanding := sale.Amount - applied
+		if outstanding <= 0 {
+			continue
+		}
+
+		y, m, d := sale.CreatedAt.In(loc).Date()
+		age := int(asOfDate.Sub(time.Date(y, m, d, 0, 0, 0, 0, loc)).Hours() / 24)
+
+		switch {
+		case age <= 30:
+			cur.Days0To30 += outstanding
+			res.Days0To30 += outstanding
+		case age <= 60:
+			cur.Days31To60 += outstanding
+			res.Days31To60 += outstanding
+		default:
+			cur.Over60 += outstanding
+			res.Over60 += outstanding
+		}
+		cur.Total += outstanding
+		res.Total += outstanding
+
+		cur.Invoices = append(cur.Invoices, dto.AgingInvoice{
+			TransactionID: sale.TransactionID,
+			InvoiceNumber: sale.InvoiceNumber,
+			CreatedAt:     sale.CreatedAt,
+			AgeDays:       age,
+			Amount:        sale.Amount,
+			Outstanding:   outstanding,
+		})
+	}
+	if cur != nil && cur.Total > 0 {
+		res.Customers = append(res.Customers, *cur)
+	}
+
+	log.Info("out", zap.Int("customers", len(res.Customers)), zap.Int("total", res.Total))
+
+	return res, nil
+}
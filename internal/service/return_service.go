@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type ReturnService interface {
+	CreateReturn(ctx context.Context, trxID uint, req dto.CreateReturn) (dto.TransactionReturn, error)
+	GetReturnsByTransactionID(ctx context.Context, trxID uint) ([]dto.TransactionReturn, error)
+}
+
+type returnService struct {
+	txManager   repository.TxManager
+	productRepo repository.ProductRepository
+	trxRepo     repository.TrxRepository
+	trxDetRepo  repository.TrxDetailRepository
+	paymentRepo repository.TrxPaymentRepository
+	returnRepo  repository.TrxReturnRepository
+	shiftRepo   repository.ShiftRepository
+	loyaltyRepo repository.LoyaltyRepository
+	creditRepo  repository.CreditRepository
+}
+
+func NewReturnService(txManager repository.TxManager, productRepo repository.ProductRepository, trxRepo repository.TrxRepository, trxDetRepo repository.TrxDetailRepository, paymentRepo repository.TrxPaymentRepository, returnRepo repository.TrxReturnRepository, shiftRepo repository.ShiftRepository, loyaltyRepo repository.LoyaltyRepository, creditRepo repository.CreditRepository) ReturnService {
+	return &returnService{txManager: txManager, productRepo: productRepo, trxRepo: trxRepo, trxDetRepo: trxDetRepo, paymentRepo: paymentRepo, returnRepo: returnRepo, shiftRepo: shiftRepo, loyaltyRepo: loyaltyRepo, creditRepo: creditRepo}
+}
+
+func (s *returnService) CreateReturn(ctx context.Context, trxID uint, req dto.CreateReturn) (dto.TransactionReturn, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ReturnService.CreateReturn"),
+		zap.Uint("transaction_id", trxID),
+	)
+
+	log.Info("in")
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		log.Warn("out", zap.String("result", "reason_is_required"))
+		return dto.TransactionReturn{}, InvalidInput("Reason is required")
+	}
+
+	if len(req.Items) <= 0 {
+		log.Warn("out", zap.String("result", "invalid_items"))
+		return dto.TransactionReturn{}, InvalidInput("Items must be > 0")
+	}
+
+	refundMethod, err := normalizeRefundMethod(req.RefundMethod)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_refund_method"))
+		return dto.TransactionReturn{}, err
+	}
+
+	terminalID, err := normalizeTerminalID(req.TerminalID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.TransactionReturn{}, err
+	}
+
+	// gabung baris yang menunjuk transaction_detail yang sama
+	qtyByDetail := map[uint]int{}
+	var order []uint
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			log.Warn("out", zap.String("result", "invalid_quantity"))
+			return dto.TransactionReturn{}, InvalidInput("Quantity must be > 0")
+		}
+		if _, ok := qtyByDetail[item.TransactionDetailID]; !ok {
+			order = append(order, item.TransactionDetailID)
+		}
+		qtyByDetail[item.TransactionDetailID] += item.Quantity
+	}
+
+	var res dto.TransactionReturn
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock header supaya retur & void paralel di transaction yang sama berurutan
+		trx, err := s.trxRepo.LockByID(ctx, trxID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "not_found"))
+				return NotFound("Transaction not found")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		if trx.Status != entity.TrxStatusCompleted {
+			log.Warn("out", zap.String("result", "conflict"), zap.String("status", trx.Status))
+			return Conflict("Transaction is not completed")
+		}
+
+		details, err := s.trxDetRepo.FindByTransactionIDs(ctx, []uint{trx.ID})
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		detailByID := make(map[uint]dto.TransactionDetail, len(details))
+		for _, d := range details {
+			detailByID[d.ID] = d
+		}
+
+		var refundTotal int
+		var lines []entity.TransactionReturnDetail
+		for _, detailID := range order {
+			qty := qtyByDetail[detailID]
+
+			d, ok := detailByID[detailID]
+			if !ok {
+				log.Warn("out", zap.String("result", "detail_not_found"), zap.Uint("transaction_detail_id", detailID))
+				return NotFound("Transaction detail not found")
+			}
+
+			if d.ReturnedQuantity+qty > d.Quantity {
+				log.Warn("out", zap.String("result", "return_exceeds_sold"), zap.Uint("transaction_detail_id", detailID))
+				return BadRequest("Return quantity exceeds sold quantity")
+			}
+
+			if err := s.trxDetRepo.AddReturnedQuantity(ctx, d.ID, qty); err != nil {
+				if errors.Is(err, repository.ErrConflict) {
+					log.Warn("out", zap.String("result", "return_exceeds_sold"), zap.Uint("transaction_detail_id", detailID))
+					return BadRequest("Return quantity exceeds sold quantity")
+				}
+				log.Error("out", zap.Error(err))
+				return err
+			}
+
+			// Restock product
+			if err := s.productRepo.IncreaseStock(ctx, d.ProductID, qty); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					log.Warn("out", zap.String("result", "product_not_found"), zap.Uint("product_id", d.ProductID))
+					return NotFound("Product not found")
+				}
+				log.Error("out", zap.Error(err))
+				return err
+			}
+
+			refund := proratedAmount(linePaidAmount(d), d.Quantity, d.ReturnedQuantity, d.ReturnedQuantity+qty)
+			refundTotal += refund
+
+			lines = append(lines, entity.TransactionReturnDetail{
+				TransactionDetailID: d.ID,
+				ProductID:           d.ProductID,
+				Quantity:            qty,
+				RefundAmount:        refund,
+			})
+		}
+
+		// Bagian yang dibayar pakai poin dikembalikan sebagai poin (bukan uang), bagian kasbon mengurangi
+		// piutang dan poin yang didapat dari unit yang diretur ditarik lagi, semuanya prorata nilai yang diretur
+		var pointsAmount, creditAmount int
+		if trx.CustomerID != nil {
+			payments, err := s.paymentRepo.FindByTransactionIDs(ctx, []uint{trx.ID})
+			if err != nil {
+				log.Error("out", zap.Error(err))
+				return err
+			}
+
+			paidTotal, returnedBefore := returnedPaid(details)
+			returnedAfter := returnedBefore + refundTotal
+
+			pointsAmount = proratedAmount(paymentTotal(payments, entity.PaymentMethodPoints), paidTotal, returnedBefore, returnedAfter)
+			redeemed := proratedAmount(trx.PointsRedeemed, paidTotal, returnedBefore, returnedAfter)
+			earned := proratedAmount(trx.PointsEarned, paidTotal, returnedBefore, returnedAfter)
+			if err := postLoyalty(ctx, s.loyaltyRepo, trx, entity.LoyaltyReversal, redeemed-earned, "return "+trx.InvoiceNumber); err != nil {
+				log.Warn("out", zap.String("result", "reverse_points_failed"), zap.Error(err))
+				return err
+			}
+
+			// kasbon yang sudah dilunasi tidak bisa dilepas lagi, sisanya dikembalikan sebagai uang
+			if credit := proratedAmount(paymentTotal(payments, entity.PaymentMethodCredit), paidTotal, returnedBefore, returnedAfter); credit > 0 {
+				creditAmount, err = s.creditRepo.Release(ctx, *trx.CustomerID, credit)
+				if err != nil {
+					if errors.Is(err, repository.ErrNotFound) {
+						log.Warn("out", zap.String("result", "customer_not_found"))
+						return NotFound("Customer not found")
+					}
+					log.Error("out", zap.Error(err))
+					return err
+				}
+			}
+		}
+		refundAmount := refundTotal - pointsAmount - creditAmount
+
+		if terminalID == "" {
+			terminalID = trx.TerminalID
+		}
+
+		shiftID, err := refundShift(ctx, s.shiftRepo, terminalID, refundMethod, refundAmount)
+		if err != nil {
+			log.Warn("out", zap.String("result", "refund_shift_failed"), zap.Error(err))
+			return err
+		}
+
+		ret, err := s.returnRepo.Create(ctx, entity.TransactionReturn{
+			TransactionID: trx.ID,
+			Reason:        reason,
+			RefundAmount:  refundAmount,
+			PointsAmount:  pointsAmount,
+			CreditAmount:  creditAmount,
+			RefundMethod:  refundMethod,
+			ShiftID:       shiftID,
+		})
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		for i := range lines {
+			lines[i].ReturnID = ret.ID
+		}
+
+		lines, err = s.returnRepo.CreateDetails(ctx, lines)
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		items := make([]dto.TransactionReturnItem, 0, len(lines))
+		for _, l := range lines {
+			items = append(items, dto.TransactionReturnItem{
+				ID:                  l.ID,
+				ReturnID:            l.ReturnID,
+				TransactionDetailID: l.TransactionDetailID,
+				ProductID:           l.ProductID,
+				ProductName:         detailByID[l.TransactionDetailID].ProductName,
+				Quantity:            l.Quantity,
+				RefundAmount:        l.RefundAmount,
+			})
+		}
+
+		res = toReturnDTO(ret, items)
+
+		return nil
+	})
+	if err != nil {
+		return dto.TransactionReturn{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("refund_amount", res.RefundAmount))
+
+	return res, nil
+}
+
+func (s *returnService) GetReturnsByTransactionID(ctx context.Context, trxID uint) ([]dto.TransactionReturn, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ReturnService.GetReturnsByTransactionID"),
+		zap.Uint("transaction_id", trxID),
+	)
+
+	log.Info("in")
+
+	if _, err := s.trxRepo.FindByID(ctx, trxID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return nil, NotFound("Transaction not found")
+		}
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	returns, err := s.returnRepo.FindByTransactionID(ctx, trxID)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(returns))
+	for _, r := range returns {
+		ids = append(ids, r.ID)
+	}
+
+	items, err := s.returnRepo.FindDetailsByReturnIDs(ctx, ids)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	byReturn := make(map[uint][]dto.TransactionReturnItem, len(returns))
+	for _, it := range items {
+		byReturn[it.ReturnID] = append(byReturn[it.ReturnID], it)
+	}
+
+	res := make([]dto.TransactionReturn, 0, len(returns))
+	for _, r := range returns {
+		res = append(res, toReturnDTO(r, byReturn[r.ID]))
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func toReturnDTO(ret entity.TransactionReturn, items []dto.TransactionReturnItem) dto.TransactionReturn {
+	if items == nil {
+		items = []dto.TransactionReturnItem{}
+	}
+
+	return dto.TransactionReturn{
+		ID:            ret.ID,
+		TransactionID: ret.TransactionID,
+		Reason:        ret.Reason,
+		RefundAmount:  ret.RefundAmount,
+		PointsAmount:  ret.PointsAmount,
+		CreditAmount:  ret.CreditAmount,
+		RefundMethod:  ret.RefundMethod,
+		ShiftID:       ret.ShiftID,
+		CreatedAt:     ret.CreatedAt,
+		Items:         items,
+	}
+}
+
+// linePaidAmount nominal yang benar-benar dibayar customer untuk satu baris transaksi:
+// nilai setelah diskon + service charge + pajak exclusive.
+func linePaidAmount(d dto.TransactionDetail) int {
+	paid := d.Subtotal + d.ServiceChargeAmount
+	if !d.TaxInclusive {
+		paid += d.TaxAmount
+	}
+	return paid
+}
+
+// returnedPaid total nilai dibayar semua baris transaksi dan bagian yang sudah diretur.
+// Dipakai untuk memprorata pembayaran poin / kasbon & poin loyalty terhadap nilai yang diretur.
+func returnedPaid(details []dto.TransactionDetail) (total int, returned int) {
+	for _, d := range details {
+		paid := linePaidAmount(d)
+		total += paid
+		returned += proratedAmount(paid, d.Quantity, 0, d.ReturnedQuantity)
+	}
+	return total, returned
+}
+
+// unreturnedShare bagian amount yang belum ikut diproses retur parsial.
+func unreturnedShare(amount int, paidTotal int, returned int) int {
+	return amount - proratedAmount(amount, paidTotal, 0, returned)
+}
+
+// proratedAmount bagian amount untuk unit ke-(from+1) s/d ke-to dari qty unit.
+// Dihitung dari selisih kumulatif supaya jumlah semua retur parsial selalu pas dengan amount.
+func proratedAmount(amount int, qty int, from int, to int) int {
+	if qty <= 0 {
+		return 0
+	}
+	return amount*to/qty - amount*from/qty
+}
@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type ShiftService interface {
+	OpenShift(ctx context.Context, req dto.OpenShift) (dto.Shift, error)
+	CloseShift(ctx context.Context, id uint, req dto.CloseShift) (dto.Shift, error)
+	GetShiftByID(ctx context.Context, id uint) (dto.Shift, error)
+	GetCurrentShift(ctx context.Context, terminalID string) (dto.Shift, error)
+	CreateCashMovement(ctx context.Context, req dto.CreateCashMovement) (dto.CashMovement, error)
+	GetCashMovements(ctx context.Context, shiftID uint) ([]dto.CashMovement, error)
+}
+
+type shiftService struct {
+	txManager repository.TxManager
+	shiftRepo repository.ShiftRepository
+	cashRepo  repository.CashMovementRepository
+}
+
+func NewShiftService(txManager repository.TxManager, shiftRepo repository.ShiftRepository, cashRepo repository.CashMovementRepository) ShiftService {
+	return &shiftService{txManager: txManager, shiftRepo: shiftRepo, cashRepo: cashRepo}
+}
+
+func (s *shiftService) OpenShift(ctx context.Context, req dto.OpenShift) (dto.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.OpenShift"),
+	)
+
+	log.Info("in")
+
+	cashier := strings.TrimSpace(req.CashierName)
+	if cashier == "" {
+		log.Warn("out", zap.String("result", "invalid_cashier_name"))
+		return dto.Shift{}, InvalidInput("Cashier name is required")
+	}
+
+	terminal, err := normalizeTerminalID(req.TerminalID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_terminal"), zap.Error(err))
+		return dto.Shift{}, err
+	}
+	if terminal == "" {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.Shift{}, InvalidInput("Terminal ID is required")
+	}
+
+	if req.OpeningFloat < 0 {
+		log.Warn("out", zap.String("result", "invalid_opening_float"))
+		return dto.Shift{}, InvalidInput("Opening float must be >= 0")
+	}
+
+	created, err := s.shiftRepo.Create(ctx, entity.Shift{
+		CashierName:  cashier,
+		TerminalID:   terminal,
+		OpeningFloat: req.OpeningFloat,
+		Status:       entity.ShiftStatusOpen,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.Shift{}, Conflict("Terminal already has an open shift")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("shift_id", created.ID))
+
+	return toShiftDTO(created, nil), nil
+}
+
+// CloseShift hitung expected cash lalu simpan selisih dengan uang yang dihitung kasir (over/short).
+func (s *shiftService) CloseShift(ctx context.Context, id uint, req dto.CloseShift) (dto.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.CloseShift"),
+		zap.Uint("shift_id", id),
+	)
+
+	log.Info("in")
+
+	if req.CountedCash == nil || *req.CountedCash < 0 {
+		log.Warn("out", zap.String("result", "invalid_counted_cash"))
+		return dto.Shift{}, InvalidInput("Counted cash must be >= 0")
+	}
+
+	var res dto.Shift
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock shift: checkout yang masih jalan di terminal ini ditunggu selesai dulu
+		shift, err := s.shiftRepo.LockByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "not_found"))
+				return NotFound("Shift not found")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		if shift.Status != entity.ShiftStatusOpen {
+			log.Warn("out", zap.String("result", "conflict"))
+			return Conflict("Shift is already closed")
+		}
+
+		sum, err := s.shiftRepo.Summary(ctx, shift.ID)
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		summary := toShiftSummaryDTO(shift, sum)
+		expected := summary.ExpectedCash
+		counted := *req.CountedCash
+		diff := counted - expected
+
+		shift.ExpectedCash = &expected
+		shift.CountedCash = &counted
+		shift.CashDifference = &diff
+		shift.ClosingNote = strings.TrimSpace(req.Note)
+
+		closed, err := s.shiftRepo.Close(ctx, shift)
+		if err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				log.Warn("out", zap.String("result", "conflict"))
+				return Conflict("Shift is already closed")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		res = toShiftDTO(closed, &summary)
+
+		return nil
+	})
+	if err != nil {
+		return dto.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("cash_difference", *res.CashDifference))
+
+	return res, nil
+}
+
+func (s *shiftService) GetShiftByID(ctx context.Context, id uint) (dto.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.GetShiftByID"),
+		zap.Uint("shift_id", id),
+	)
+
+	log.Info("in")
+
+	shift, err := s.shiftRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.Shift{}, NotFound("Shift not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.Shift{}, err
+	}
+
+	res, err := s.withSummary(ctx, shift)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return res, nil
+}
+
+func (s *shiftService) GetCurrentShift(ctx context.Context, terminalID string) (dto.Shift, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.GetCurrentShift"),
+		zap.String("terminal_id", terminalID),
+	)
+
+	log.Info("in")
+
+	terminal, err := normalizeTerminalID(terminalID)
+	if err != nil || terminal == "" {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.Shift{}, InvalidInput("Terminal ID is required")
+	}
+
+	shift, err := s.shiftRepo.FindOpenByTerminal(ctx, terminal)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.Shift{}, NotFound("No open shift on terminal")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.Shift{}, err
+	}
+
+	res, err := s.withSummary(ctx, shift)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.Shift{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("shift_id", shift.ID))
+
+	return res, nil
+}
+
+// CreateCashMovement catat uang masuk/keluar laci ke shift yang sedang open di terminal.
+func (s *shiftService) CreateCashMovement(ctx context.Context, req dto.CreateCashMovement) (dto.CashMovement, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.CreateCashMovement"),
+	)
+
+	log.Info("in")
+
+	terminal, err := normalizeTerminalID(req.TerminalID)
+	if err != nil || terminal == "" {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.CashMovement{}, InvalidInput("Terminal ID is required")
+	}
+
+	direction := strings.ToLower(strings.TrimSpace(req.Direction))
+	if direction != entity.CashDirectionIn && direction != entity.CashDirectionOut {
+		log.Warn("out", zap.String("result", "invalid_direction"))
+		return dto.CashMovement{}, InvalidInput("Direction must be in or out")
+	}
+
+	if req.Amount <= 0 {
+		log.Warn("out", zap.String("result", "invalid_amount"))
+		return dto.CashMovement{}, InvalidInput("Amount must be > 0")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		log.Warn("out", zap.String("result", "reason_is_required"))
+		return dto.CashMovement{}, InvalidInput("Reason is required")
+	}
+
+	performedBy := strings.TrimSpace(req.PerformedBy)
+	if performedBy == "" {
+		log.Warn("out", zap.String("result", "performed_by_is_required"))
+		return dto.CashMovement{}, InvalidInput("Performed by is required")
+	}
+
+	var res dto.CashMovement
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// FOR SHARE: tutup shift menunggu movement ini commit supaya ikut dihitung
+		shift, err := s.shiftRepo.FindOpenByTerminal(ctx, terminal)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "no_open_shift"))
+				return BadRequest("No open shift on terminal")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		created, err := s.cashRepo.Create(ctx, entity.CashMovement{
+			ShiftID:     shift.ID,
+			Direction:   direction,
+			Amount:      req.Amount,
+			Reason:      reason,
+			PerformedBy: performedBy,
+		})
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		res = toCashMovementDTO(created)
+
+		return nil
+	})
+	if err != nil {
+		return dto.CashMovement{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("cash_movement_id", res.ID))
+
+	return res, nil
+}
+
+func (s *shiftService) GetCashMovements(ctx context.Context, shiftID uint) ([]dto.CashMovement, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "ShiftService.GetCashMovements"),
+		zap.Uint("shift_id", shiftID),
+	)
+
+	log.Info("in")
+
+	if _, err := s.shiftRepo.FindByID(ctx, shiftID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return nil, NotFound("Shift not found")
+		}
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	movements, err := s.cashRepo.FindByShiftID(ctx, shiftID)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]dto.CashMovement, 0, len(movements))
+	for _, m := range movements {
+		res = append(res, toCashMovementDTO(m))
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func (s *shiftService) withSummary(ctx context.Context, shift entity.Shift) (dto.Shift, error) {
+	sum, err := s.shiftRepo.Summary(ctx, shift.ID)
+	if err != nil {
+		return dto.Shift{}, err
+	}
+
+	summary := toShiftSummaryDTO(shift, sum)
+	return toShiftDTO(shift, &summary), nil
+}
+
+// normalizeTerminalID trim & batasi panjang terminal id dari request.
+func normalizeTerminalID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if len(id) > 50 {
+		return "", InvalidInput("Terminal ID is too long")
+	}
+	return id, nil
+}
+
+// normalizeRefundMethod default cash; hanya method pembayaran yang dikenal.
+func normalizeRefundMethod(method string) (string, error) {
+	method = strings.ToLower(strings.TrimSpace(method))
+	if method == "" {
+		return entity.PaymentMethodCash, nil
+	}
+	if !paymentMethods[method] || method == entity.PaymentMethodCredit {
+		return "", InvalidInput("Invalid refund method")
+	}
+	return method, nil
+}
+
+// refundShift cari shift open di terminal yang mengeluarkan refund. Refund cash wajib punya shift
+// karena uangnya keluar dari laci; refund non-cash cukup dicatat ke shift kalau ada.
+func refundShift(ctx context.Context, shiftRepo repository.ShiftRepository, terminalID string, method string, amount int) (*uint, error) {
+	if terminalID == "" {
+		if method == entity.PaymentMethodCash && amount > 0 {
+			return nil, InvalidInput("Terminal ID is required for cash refund")
+		}
+		return nil, nil
+	}
+
+	shift, err := shiftRepo.FindOpenByTerminal(ctx, terminalID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			if method == entity.PaymentMethodCash && amount > 0 {
+				return nil, BadRequest("No open shift on terminal")
+			}
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &shift.ID, nil
+}
+
+func toShiftSummaryDTO(shift entity.Shift, sum entity.ShiftSummary) dto.ShiftSummary {
+	payments := make([]dto.PaymentSummary, 0, len(sum.Payments))
+	for _, p := range sum.Payments {
+		payments = append(payments, dto.PaymentSummary{
+			Method:           p.Method,
+			TransactionCount: p.TransactionCount,
+			Amount:           p.Amount,
+		})
+	}
+
+	var cashRefunded int
+	refunds := make([]dto.RefundSummary, 0, len(sum.Refunds))
+	for _, r := range sum.Refunds {
+		if r.Method == entity.PaymentMethodCash {
+			cashRefunded += r.Amount
+		}
+		refunds = append(refunds, dto.RefundSummary{Method: r.Method, Count: r.Count, Amount: r.Amount})
+	}
+
+	return dto.ShiftSummary{
+		TransactionCount:    sum.TransactionCount,
+		SalesAmount:         sum.SalesAmount,
+		CashReceived:        sum.CashReceived,
+		ChangeGiven:         sum.ChangeGiven,
+		CashRefunded:        cashRefunded,
+		CashIn:              sum.CashIn,
+		CashOut:             sum.CashOut,
+		CreditRepaymentCash: sum.CreditRepaymentCash,
+		ExpectedCash:        shift.OpeningFloat + sum.CashReceived - sum.ChangeGiven - cashRefunded + sum.CashIn - sum.CashOut + sum.CreditRepaymentCash,
+		Payments:            payments,
+		Refunds:             refunds,
+	}
+}
+
+func toShiftDTO(s entity.Shift, summary *dto.ShiftSummary) dto.Shift {
+	return dto.Shift{
+		ID:             s.ID,
+		CashierName:    s.CashierName,
+		TerminalID:     s.TerminalID,
+		OpeningFloat:   s.OpeningFloat,
+		Status:         s.Status,
+		ExpectedCash:   s.ExpectedCash,
+		CountedCash:    s.CountedCash,
+		CashDifference: s.CashDifference,
+		ClosingNote:    s.ClosingNote,
+		OpenedAt:       s.OpenedAt,
+		ClosedAt:       s.ClosedAt,
+		Summary:        summary,
+	}
+}
+
+func toCashMovementDTO(m entity.CashMovement) dto.CashMovement {
+	return dto.CashMovement{
+		ID:          m.ID,
+		ShiftID:     m.ShiftID,
+		Direction:   m.Direction,
+		Amount:      m.Amount,
+		Reason:      m.Reason,
+		PerformedBy: m.PerformedBy,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/receipt"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type StoreSettingService interface {
+	GetStoreSetting(ctx context.Context) (dto.StoreSettingResponse, error)
+	UpdateStoreSetting(ctx context.Context, req dto.StoreSetting) (dto.StoreSettingResponse, error)
+}
+
+type storeSettingService struct {
+	storeRepo repository.StoreSettingRepository
+}
+
+func NewStoreSettingService(storeRepo repository.StoreSettingRepository) StoreSettingService {
+	return &storeSettingService{storeRepo: storeRepo}
+}
+
+func (s *storeSettingService) GetStoreSetting(ctx context.Context) (dto.StoreSettingResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "StoreSettingService.GetStoreSetting"),
+	)
+
+	log.Info("in")
+
+	setting, err := s.storeRepo.Get(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.StoreSettingResponse{}, NotFound("Store setting not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.StoreSettingResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toStoreSettingDTO(setting), nil
+}
+
+func (s *storeSettingService) UpdateStoreSetting(ctx context.Context, req dto.StoreSetting) (dto.StoreSettingResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "StoreSettingService.UpdateStoreSetting"),
+	)
+
+	log.Info("in")
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		log.Warn("out", zap.String("result", "invalid_name"))
+		return dto.StoreSettingResponse{}, InvalidInput("Name is required")
+	}
+
+	// template divalidasi saat disimpan supaya invoice tidak gagal render belakangan
+	if _, err := receipt.ParseInvoiceTemplate(req.InvoiceTemplate); err != nil {
+		log.Warn("out", zap.String("result", "invalid_invoice_template"), zap.Error(err))
+		return dto.StoreSettingResponse{}, InvalidInput("Invalid invoice template: " + err.Error())
+	}
+	if _, err := receipt.ParseInvoicePDFTemplate(req.InvoicePDFTemplate); err != nil {
+		log.Warn("out", zap.String("result", "invalid_invoice_pdf_template"), zap.Error(err))
+		return dto.StoreSettingResponse{}, InvalidInput("Invalid invoice PDF template: " + err.Error())
+	}
+
+	updated, err := s.storeRepo.Update(ctx, entity.StoreSetting{
+		Name:               req.Name,
+		Address:            strings.TrimSpace(req.Address),
+		Phone:              strings.TrimSpace(req.Phone),
+		NPWP:               strings.TrimSpace(req.NPWP),
+		ReceiptFooter:      strings.TrimSpace(req.ReceiptFooter),
+		InvoiceTemplate:    req.InvoiceTemplate,
+		InvoicePDFTemplate: req.InvoicePDFTemplate,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.StoreSettingResponse{}, NotFound("Store setting not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.StoreSettingResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toStoreSettingDTO(updated), nil
+}
+
+func toStoreSettingDTO(s entity.StoreSetting) dto.StoreSettingResponse {
+	return dto.StoreSettingResponse{
+		Name:               s.Name,
+		Address:            s.Address,
+		Phone:              s.Phone,
+		NPWP:               s.NPWP,
+		ReceiptFooter:      s.ReceiptFooter,
+		InvoiceTemplate:    s.InvoiceTemplate,
+		InvoicePDFTemplate: s.InvoicePDFTemplate,
+		UpdatedAt:          s.UpdatedAt,
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type TaxRateService interface {
+	CreateTaxRate(ctx context.Context, req dto.TaxRate) (dto.TaxRateResponse, error)
+	GetTaxRateByID(ctx context.Context, id uint) (dto.TaxRateResponse, error)
+	GetAllTaxRate(ctx context.Context) ([]dto.TaxRateResponse, error)
+	UpdateTaxRateByID(ctx context.Context, id uint, req dto.TaxRate) (dto.TaxRateResponse, error)
+	DeleteTaxRateByID(ctx context.Context, id uint) error
+}
+
+type taxRateService struct {
+	taxRateRepo  repository.TaxRateRepository
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewTaxRateService(taxRateRepo repository.TaxRateRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository) TaxRateService {
+	return &taxRateService{taxRateRepo: taxRateRepo, categoryRepo: categoryRepo, productRepo: productRepo}
+}
+
+func (s *taxRateService) CreateTaxRate(ctx context.Context, req dto.TaxRate) (dto.TaxRateResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TaxRateService.CreateTaxRate"),
+	)
+
+	log.Info("in")
+
+	t, err := s.validate(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.TaxRateResponse{}, err
+	}
+
+	created, err := s.taxRateRepo.Create(ctx, t)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.TaxRateResponse{}, Conflict("Tax rate for this target already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.TaxRateResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("tax_rate_id", created.ID))
+
+	return toTaxRateDTO(created), nil
+}
+
+func (s *taxRateService) GetTaxRateByID(ctx context.Context, id uint) (dto.TaxRateResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TaxRateService.GetTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	t, err := s.taxRateRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.TaxRateResponse{}, NotFound("Tax rate not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.TaxRateResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toTaxRateDTO(t), nil
+}
+
+func (s *taxRateService) GetAllTaxRate(ctx context.Context) ([]dto.TaxRateResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TaxRateService.GetAllTaxRate"),
+	)
+
+	log.Info("in")
+
+	rates, err := s.taxRateRepo.FindAll(ctx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]dto.TaxRateResponse, 0, len(rates))
+	for _, t := range rates {
+		res = append(res, toTaxRateDTO(t))
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func (s *taxRateService) UpdateTaxRateByID(ctx context.Context, id uint, req dto.TaxRate) (dto.TaxRateResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TaxRateService.UpdateTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	t, err := s.validate(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.TaxRateResponse{}, err
+	}
+	t.ID = id
+
+	updated, err := s.taxRateRepo.Update(ctx, t)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.TaxRateResponse{}, NotFound("Tax rate not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.TaxRateResponse{}, Conflict("Tax rate for this target already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.TaxRateResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toTaxRateDTO(updated), nil
+}
+
+func (s *taxRateService) DeleteTaxRateByID(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TaxRateService.DeleteTaxRateByID"),
+	)
+
+	log.Info("in")
+
+	if err := s.taxRateRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Tax rate not found")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (s *taxRateService) validate(ctx context.Context, req dto.TaxRate) (entity.TaxRate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return entity.TaxRate{}, InvalidInput("Name is required")
+	}
+
+	if req.Rate < 0 || req.Rate > 100 {
+		return entity.TaxRate{}, InvalidInput("Rate must be between 0 and 100")
+	}
+
+	if req.CategoryID != nil && req.ProductID != nil {
+		return entity.TaxRate{}, InvalidInput("Tax rate can target either a category or a product")
+	}
+
+	if req.CategoryID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *req.CategoryID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.TaxRate{}, NotFound("Category not found")
+			}
+			return entity.TaxRate{}, err
+		}
+	}
+
+	if req.ProductID != nil {
+		if _, err := s.productRepo.FindByID(ctx, *req.ProductID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.TaxRate{}, NotFound("Product not found")
+			}
+			return entity.TaxRate{}, err
+		}
+	}
+
+	return entity.TaxRate{
+		Name:       name,
+		Rate:       req.Rate,
+		Inclusive:  req.Inclusive,
+		CategoryID: req.CategoryID,
+		ProductID:  req.ProductID,
+	}, nil
+}
+
+func toTaxRateDTO(t entity.TaxRate) dto.TaxRateResponse {
+	return dto.TaxRateResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Rate:       t.Rate,
+		Inclusive:  t.Inclusive,
+		CategoryID: t.CategoryID,
+		ProductID:  t.ProductID,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
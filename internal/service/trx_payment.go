@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"strings"
+)
+
+var paymentMethods = map[string]bool{
+	entity.PaymentMethodCash:       true,
+	entity.PaymentMethodQRIS:       true,
+	entity.PaymentMethodDebitCard:  true,
+	entity.PaymentMethodCreditCard: true,
+	entity.PaymentMethodTransfer:   true,
+	entity.PaymentMethodCredit:     true,
+}
+
+// normalizePayments validasi method & amount tiap pembayaran (tanpa melihat total).
+func normalizePayments(payments []dto.CheckoutPayment) ([]dto.CheckoutPayment, error) {
+	if len(payments) == 0 {
+		return nil, InvalidInput("Payments is required")
+	}
+
+	out := make([]dto.CheckoutPayment, 0, len(payments))
+	for _, p := range payments {
+		p.Method = strings.ToLower(strings.TrimSpace(p.Method))
+		p.Reference = strings.TrimSpace(p.Reference)
+
+		if !paymentMethods[p.Method] {
+			return nil, InvalidInput("Invalid payment method")
+		}
+
+		if p.Amount <= 0 {
+			return nil, InvalidInput("Payment amount must be > 0")
+		}
+
+		out = append(out, p)
+	}
+
+	return out, nil
+}
+
+// checkoutPayments normalize payments dari request lalu tambahkan pembayaran poin kalau
+// customer menukar poin. Poin diperlakukan sebagai pembayaran non-cash (bukan diskon),
+// jadi harga, DPP & pajak tidak berubah.
+func checkoutPayments(req dto.Checkout, loyalty LoyaltyConfig) ([]dto.CheckoutPayment, error) {
+	if req.RedeemPoints < 0 {
+		return nil, InvalidInput("Redeem points must be >= 0")
+	}
+
+	var payments []dto.CheckoutPayment
+	if len(req.Payments) > 0 || req.RedeemPoints == 0 {
+		var err error
+		if payments, err = normalizePayments(req.Payments); err != nil {
+			return nil, err
+		}
+	}
+
+	// kasbon dicatat ke piutang customer, jadi wajib ada customer
+	if req.CustomerID == nil && paidWith(payments, entity.PaymentMethodCredit) > 0 {
+		return nil, InvalidInput("Customer is required for credit payment")
+	}
+
+	if req.RedeemPoints == 0 {
+		return payments, nil
+	}
+
+	if req.CustomerID == nil {
+		return nil, InvalidInput("Customer is required to redeem points")
+	}
+	if loyalty.PointValue <= 0 {
+		return nil, BadRequest("Points redemption is disabled")
+	}
+
+	return append(payments, dto.CheckoutPayment{
+		Method:    entity.PaymentMethodPoints,
+		Amount:    req.RedeemPoints * loyalty.PointValue,
+		Reference: fmt.Sprintf("%d points", req.RedeemPoints),
+	}), nil
+}
+
+type settlement struct {
+	Paid     int
+	Change   int
+	Rounding int
+}
+
+// settlePayments cek pembayaran menutup total dan hitung kembalian.
+// Kembalian hanya boleh dari cash: non-cash tidak boleh melebihi total.
+// Sisa tagihan yang dibayar cash dibulatkan sesuai rounding policy; selisihnya disimpan terpisah.
+func settlePayments(payments []dto.CheckoutPayment, total int, rounding RoundingPolicy) (settlement, error) {
+	var cash, nonCash int
+	for _, p := range payments {
+		if p.Method == entity.PaymentMethodCash {
+			cash += p.Amount
+		} else {
+			nonCash += p.Amount
+		}
+	}
+
+	if nonCash > total {
+		return settlement{}, InvalidInput("Non-cash payments exceed total")
+	}
+
+	var adjustment int
+	if cash > 0 {
+		adjustment = cashRounding(total, nonCash, rounding)
+	}
+
+	due := total + adjustment
+	paid := cash + nonCash
+	if paid < due {
+		return settlement{}, BadRequest("Payments do not cover total")
+	}
+
+	return settlement{Paid: paid, Change: paid - due, Rounding: adjustment}, nil
+}
+
+// cashRounding selisih pembulatan untuk sisa tagihan setelah pembayaran non-cash.
+// Hanya berlaku kalau sisa tersebut dibayar cash.
+func cashRounding(total, nonCash int, rounding RoundingPolicy) int {
+	cashDue := total - nonCash
+	return rounding.Apply(cashDue) - cashDue
+}
+
+// paymentTotal total pembayaran tersimpan dengan method tertentu.
+func paymentTotal(payments []entity.TransactionPayment, method string) int {
+	total := 0
+	for _, p := range payments {
+		if p.Method == method {
+			total += p.Amount
+		}
+	}
+	return total
+}
+
+// paidWith total pembayaran dengan method tertentu.
+func paidWith(payments []dto.CheckoutPayment, method string) int {
+	total := 0
+	for _, p := range payments {
+		if p.Method == method {
+			total += p.Amount
+		}
+	}
+	return total
+}
@@ -2,30 +2,54 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"kasir-api/internal/delivery/http/middleware"
 	"kasir-api/internal/dto"
 	"kasir-api/internal/entity"
 	"kasir-api/internal/repository"
+	"math"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type TrxService interface {
-	Checkout(ctx context.Context, req dto.Checkout) (dto.Transaction, error)
+	Checkout(ctx context.Context, req dto.Checkout, idempotencyKey string) (dto.Transaction, error)
+	Quote(ctx context.Context, req dto.Checkout) (dto.Quote, error)
+	GetTransactionByID(ctx context.Context, id uint) (dto.Transaction, error)
+	GetAllTransaction(ctx context.Context, f dto.TransactionFilter) (dto.TransactionList, error)
+	VoidTransaction(ctx context.Context, id uint, req dto.ReverseTransaction) (dto.Transaction, error)
+	RefundTransaction(ctx context.Context, id uint, req dto.ReverseTransaction) (dto.Transaction, error)
 }
 
 type trxService struct {
-	productRepo repository.ProductRepository
-	trxRepo     repository.TrxRepository
-	trxDetRepo  repository.TrxDetailRepository
+	txManager    repository.TxManager
+	productRepo  repository.ProductRepository
+	trxRepo      repository.TrxRepository
+	trxDetRepo   repository.TrxDetailRepository
+	paymentRepo  repository.TrxPaymentRepository
+	taxRateRepo  repository.TaxRateRepository
+	idemRepo     repository.IdempotencyRepository
+	invoiceRepo  repository.InvoiceCounterRepository
+	shiftRepo    repository.ShiftRepository
+	customerRepo repository.CustomerRepository
+	groupRepo    repository.CustomerGroupRepository
+	loyaltyRepo  repository.LoyaltyRepository
+	creditRepo   repository.CreditRepository
+	promoRepo    repository.PromotionRepository
+	voucherRepo  repository.VoucherRepository
+	cfg          CheckoutConfig
 }
 
-func NewTrxService(productRepo repository.ProductRepository, trxRepo repository.TrxRepository, trxDetRepo repository.TrxDetailRepository) TrxService {
-	return &trxService{productRepo: productRepo, trxRepo: trxRepo, trxDetRepo: trxDetRepo}
+func NewTrxService(txManager repository.TxManager, productRepo repository.ProductRepository, trxRepo repository.TrxRepository, trxDetRepo repository.TrxDetailRepository, paymentRepo repository.TrxPaymentRepository, taxRateRepo repository.TaxRateRepository, idemRepo repository.IdempotencyRepository, invoiceRepo repository.InvoiceCounterRepository, shiftRepo repository.ShiftRepository, customerRepo repository.CustomerRepository, groupRepo repository.CustomerGroupRepository, loyaltyRepo repository.LoyaltyRepository, creditRepo repository.CreditRepository, promoRepo repository.PromotionRepository, voucherRepo repository.VoucherRepository, cfg CheckoutConfig) TrxService {
+	return &trxService{txManager: txManager, productRepo: productRepo, trxRepo: trxRepo, trxDetRepo: trxDetRepo, paymentRepo: paymentRepo, taxRateRepo: taxRateRepo, idemRepo: idemRepo, invoiceRepo: invoiceRepo, shiftRepo: shiftRepo, customerRepo: customerRepo, groupRepo: groupRepo, loyaltyRepo: loyaltyRepo, creditRepo: creditRepo, promoRepo: promoRepo, voucherRepo: voucherRepo, cfg: cfg}
 }
 
-func (s *trxService) Checkout(ctx context.Context, req dto.Checkout) (dto.Transaction, error) {
+func (s *trxService) Checkout(ctx context.Context, req dto.Checkout, idempotencyKey string) (dto.Transaction, error) {
 	log := middleware.LoggerFromCtx(ctx).With(
 		zap.String("layer", "service"),
 		zap.String("operation", "TrxService.Checkout"),
@@ -33,91 +57,990 @@ func (s *trxService) Checkout(ctx context.Context, req dto.Checkout) (dto.Transa
 
 	log.Info("in")
 
-	if len(req.Items) <= 0 {
-		log.Warn("out", zap.String("result", "invalid_items"))
-		return dto.Transaction{}, InvalidInput("Items must be > 0")
+	if err := validateCheckoutItems(req.Items); err != nil {
+		log.Warn("out", zap.String("result", "invalid_items"), zap.Error(err))
+		return dto.Transaction{}, err
 	}
 
-	var total int
-	var details []dto.TransactionDetail
-	// Loop item checkout
-	items := req.Items
-	for _, item := range items {
-		if item.Quantity <= 0 {
-			log.Warn("out", zap.String("result", "invalid_quantity"))
-			return dto.Transaction{}, InvalidInput("Quantity must be > 0")
+	payments, err := checkoutPayments(req, s.cfg.Loyalty)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_payments"), zap.Error(err))
+		return dto.Transaction{}, err
+	}
+
+	// Checkout selalu terikat ke shift open di terminal kasir
+	req.TerminalID, err = normalizeTerminalID(req.TerminalID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_terminal"), zap.Error(err))
+		return dto.Transaction{}, err
+	}
+	if req.TerminalID == "" {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.Transaction{}, InvalidInput("Terminal ID is required")
+	}
+
+	if len(idempotencyKey) > 255 {
+		log.Warn("out", zap.String("result", "invalid_idempotency_key"))
+		return dto.Transaction{}, InvalidInput("Idempotency-Key is too long")
+	}
+
+	var res dto.Transaction
+	replayed := false
+	// Semua perubahan stock, transaction & detail commit/rollback bersama
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if idempotencyKey != "" {
+			found, err := s.replayIdempotent(ctx, idempotencyKey, req, &res)
+			if err != nil {
+				return err
+			}
+			if found {
+				replayed = true
+				return nil
+			}
 		}
 
-		// Get product
-		curProduct, err := s.productRepo.FindByID(ctx, item.ProductID)
-		if errors.Is(err, repository.ErrNotFound) {
-			log.Warn("out", zap.String("result", "not_found"))
-			return dto.Transaction{}, NotFound("Product not found")
+		shift, err := s.shiftRepo.FindOpenByTerminal(ctx, req.TerminalID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "no_open_shift"))
+				return BadRequest("No open shift on terminal")
+			}
+			log.Error("out", zap.Error(err))
+			return err
 		}
 
-		if curProduct.Stock < item.Quantity {
-			log.Warn("out", zap.String("result", "bad_request"))
-			return dto.Transaction{}, BadRequest("Stock not enough")
+		// Calculate (customer dicek di sini juga karena menentukan price list)
+		pricing, err := s.priceCheckout(ctx, req)
+		if err != nil {
+			log.Warn("out", zap.String("result", "pricing_failed"), zap.Error(err))
+			return err
 		}
 
-		// Update product stock
-		if _, err := s.productRepo.Update(ctx, entity.Product{
-			ID:    curProduct.ID,
-			Stock: curProduct.Stock - item.Quantity,
-		}); err != nil {
+		// Update product stock sekaligus (conditional decrement, race-free)
+		qtyByID := make(map[uint]int, len(pricing.Lines))
+		for _, line := range pricing.Lines {
+			qtyByID[line.Product.ID] += line.Quantity
+		}
+
+		if err := s.productRepo.DecreaseStockBatch(ctx, qtyByID); err != nil {
+			if errors.Is(err, repository.ErrInsufficientStock) {
+				log.Warn("out", zap.String("result", "bad_request"))
+				return BadRequest("Stock not enough")
+			}
 			if errors.Is(err, repository.ErrNotFound) {
 				log.Warn("out", zap.String("result", "not_found"))
-				return dto.Transaction{}, NotFound("Product not found")
+				return NotFound("Product not found")
 			}
 			log.Error("out", zap.Error(err))
-			return dto.Transaction{}, err
+			return err
 		}
 
-		// Calculate
-		subtotal := curProduct.Price * item.Quantity
-		total += subtotal
+		settled, err := settlePayments(payments, pricing.Total, s.cfg.Rounding)
+		if err != nil {
+			log.Warn("out", zap.String("result", "payment_not_settled"), zap.Error(err))
+			return err
+		}
 
-		// Save to struct
-		detail := dto.TransactionDetail{
-			ProductID:   curProduct.ID,
-			ProductName: curProduct.Name,
-			Quantity:    item.Quantity,
-			Subtotal:    subtotal,
+		// Kasbon masuk ke piutang customer, ditolak kalau melewati credit limit
+		if credit := paidWith(payments, entity.PaymentMethodCredit); credit > 0 {
+			if err := s.creditRepo.Charge(ctx, *req.CustomerID, credit); err != nil {
+				if errors.Is(err, repository.ErrCreditLimit) {
+					log.Warn("out", zap.String("result", "credit_limit_exceeded"))
+					return BadRequest("Credit limit exceeded")
+				}
+				if errors.Is(err, repository.ErrNotFound) {
+					log.Warn("out", zap.String("result", "customer_not_found"))
+					return NotFound("Customer not found")
+				}
+				log.Error("out", zap.Error(err))
+				return err
+			}
 		}
 
-		details = append(details, detail)
-	}
+		// Poin hanya dari nominal yang dibayar dengan uang
+		var pointsEarned int
+		if req.CustomerID != nil {
+			pointsEarned = s.cfg.Loyalty.earn(pricing.Total + settled.Rounding - paidWith(payments, entity.PaymentMethodPoints))
+		}
+
+		// Nomor invoice diambil paling akhir sebelum insert supaya lock counter dipegang sesingkat mungkin.
+		// Tanggal di nomor invoice dan created_at dari satu timestamp yang sama supaya tidak beda hari
+		// kalau checkout pas lewat tengah malam.
+		now := s.cfg.Invoice.period(time.Now())
+		seq, err := s.invoiceRepo.Next(ctx, s.cfg.Invoice.counterScope(req.TerminalID), now)
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		// Insert transaction
+		trxRes, err := s.trxRepo.Create(ctx, entity.Transaction{
+			InvoiceNumber:       s.cfg.Invoice.render(now, req.TerminalID, seq),
+			TerminalID:          req.TerminalID,
+			ShiftID:             &shift.ID,
+			CustomerID:          req.CustomerID,
+			PointsEarned:        pointsEarned,
+			PointsRedeemed:      req.RedeemPoints,
+			SubtotalAmount:      pricing.Subtotal,
+			DiscountAmount:      pricing.DiscountTotal(),
+			CartDiscountAmount:  pricing.CartDiscount,
+			PromotionAmount:     pricing.Promotion,
+			VoucherID:           voucherID(pricing.Voucher),
+			VoucherCode:         voucherCode(pricing.Voucher),
+			VoucherAmount:       pricing.VoucherAmount,
+			ServiceChargeAmount: pricing.ServiceCharge,
+			TaxBaseAmount:       pricing.TaxBase,
+			TaxAmount:           pricing.TaxAmount,
+			TotalAmount:         pricing.Total,
+			RoundingAmount:      settled.Rounding,
+			PaidAmount:          settled.Paid,
+			ChangeAmount:        settled.Change,
+			Status:              entity.TrxStatusCompleted,
+			CreatedAt:           now,
+		})
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		// Kuota voucher dipotong atomic, checkout paralel dengan kode sekali pakai hanya lolos satu
+		if pricing.Voucher != nil {
+			if _, err := s.voucherRepo.Redeem(ctx, *pricing.Voucher, entity.VoucherRedemption{
+				TransactionID: trxRes.ID,
+				CustomerID:    req.CustomerID,
+				Amount:        pricing.VoucherAmount,
+			}); err != nil {
+				if errors.Is(err, repository.ErrVoucherUnavailable) {
+					log.Warn("out", zap.String("result", "voucher_unavailable"))
+					return BadRequest("Voucher usage limit reached")
+				}
+				log.Error("out", zap.Error(err))
+				return err
+			}
+		}
+
+		// Redeem pakai conditional update saldo, jadi poin yang sama tidak bisa dipakai dua checkout
+		if err := postLoyalty(ctx, s.loyaltyRepo, trxRes, entity.LoyaltyRedeem, -trxRes.PointsRedeemed, trxRes.InvoiceNumber); err != nil {
+			log.Warn("out", zap.String("result", "redeem_points_failed"), zap.Error(err))
+			return err
+		}
 
-	// Insert transaction
-	trxRes, err := s.trxRepo.Create(ctx, entity.Transaction{TotalAmount: total})
+		if err := postLoyalty(ctx, s.loyaltyRepo, trxRes, entity.LoyaltyEarn, trxRes.PointsEarned, trxRes.InvoiceNumber); err != nil {
+			log.Warn("out", zap.String("result", "earn_points_failed"), zap.Error(err))
+			return err
+		}
+
+		// Insert transaction detail (satu batch insert)
+		trxDetails := make([]entity.TransactionDetail, 0, len(pricing.Lines))
+		for _, line := range pricing.Lines {
+			trxDetails = append(trxDetails, entity.TransactionDetail{
+				TransactionID:       trxRes.ID,
+				ProductID:           line.Product.ID,
+				ProductName:         line.Product.Name,
+				CategoryID:          line.Product.CategoryID,
+				CategoryName:        line.Product.CategoryName,
+				BasePrice:           line.BasePrice,
+				UnitPrice:           line.UnitPrice,
+				PriceListID:         line.PriceList.id(),
+				PriceListName:       line.PriceList.name(),
+				Quantity:            line.Quantity,
+				DiscountAmount:      line.LineDiscount,
+				CartDiscountAmount:  line.CartDiscount,
+				PromotionAmount:     line.Promotion,
+				PromotionName:       line.PromotionName,
+				VoucherAmount:       line.Voucher,
+				Subtotal:            line.Net(),
+				ServiceChargeAmount: line.ServiceCharge,
+				TaxName:             line.Tax.Name,
+				TaxRate:             line.Tax.Rate,
+				TaxInclusive:        line.Tax.Inclusive,
+				TaxBaseAmount:       line.TaxBase,
+				TaxAmount:           line.TaxAmount,
+			})
+		}
+
+		trxDetails, err = s.trxDetRepo.CreateBatch(ctx, trxDetails)
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		details := make([]dto.TransactionDetail, 0, len(trxDetails))
+		for _, d := range trxDetails {
+			details = append(details, toTransactionDetailDTO(d))
+		}
+
+		// Insert payments
+		trxPayments := make([]entity.TransactionPayment, 0, len(payments))
+		for _, p := range payments {
+			trxPayments = append(trxPayments, entity.TransactionPayment{
+				TransactionID: trxRes.ID,
+				Method:        p.Method,
+				Amount:        p.Amount,
+				Reference:     p.Reference,
+			})
+		}
+
+		trxPayments, err = s.paymentRepo.CreateBatch(ctx, trxPayments)
+		if err != nil {
+			log.Warn("out", zap.String("result", "repository_error"))
+			return err
+		}
+
+		res = toTransactionDTO(trxRes, details, trxPayments)
+
+		if idempotencyKey != "" {
+			body, err := json.Marshal(res)
+			if err != nil {
+				log.Error("out", zap.Error(err))
+				return err
+			}
+
+			if err := s.idemRepo.SaveResponse(ctx, idempotencyKey, res.ID, string(body)); err != nil {
+				log.Warn("out", zap.String("result", "repository_error"))
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		log.Warn("out", zap.String("result", "repository_error"))
 		return dto.Transaction{}, err
 	}
 
-	// Insert transaction detail
-	for i := range details {
-		trxDetRes, err := s.trxDetRepo.Create(ctx, entity.TransactionDetail{
-			TransactionID: trxRes.ID,
-			ProductID:     details[i].ProductID,
-			Quantity:      details[i].Quantity,
-			Subtotal:      details[i].Subtotal,
+	if replayed {
+		log.Info("out", zap.String("result", "idempotent_replay"), zap.Uint("transaction_id", res.ID))
+		return res, nil
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return res, nil
+}
+
+// Quote hitung checkout persis seperti Checkout (harga, diskon, pajak, pembulatan) tanpa menulis apapun.
+func (s *trxService) Quote(ctx context.Context, req dto.Checkout) (dto.Quote, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TrxService.Quote"),
+	)
+
+	log.Info("in")
+
+	if err := validateCheckoutItems(req.Items); err != nil {
+		log.Warn("out", zap.String("result", "invalid_items"), zap.Error(err))
+		return dto.Quote{}, err
+	}
+
+	pricing, err := s.priceCheckout(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "pricing_failed"), zap.Error(err))
+		return dto.Quote{}, err
+	}
+
+	res := dto.Quote{
+		Subtotal:            pricing.Subtotal,
+		DiscountAmount:      pricing.DiscountTotal(),
+		CartDiscountAmount:  pricing.CartDiscount,
+		PromotionAmount:     pricing.Promotion,
+		VoucherCode:         voucherCode(pricing.Voucher),
+		VoucherAmount:       pricing.VoucherAmount,
+		ServiceChargeAmount: pricing.ServiceCharge,
+		TaxBaseAmount:       pricing.TaxBase,
+		TaxAmount:           pricing.TaxAmount,
+		Total:               pricing.Total,
+		CashTotal:           pricing.Total + cashRounding(pricing.Total, 0, s.cfg.Rounding),
+		InStock:             true,
+	}
+
+	// payments opsional di quote, kalau diisi dicek sama seperti checkout
+	earnBase := pricing.Total
+	if len(req.Payments) > 0 || req.RedeemPoints != 0 {
+		payments, err := checkoutPayments(req, s.cfg.Loyalty)
+		if err != nil {
+			log.Warn("out", zap.String("result", "invalid_payments"), zap.Error(err))
+			return dto.Quote{}, err
+		}
+
+		settled, err := settlePayments(payments, pricing.Total, s.cfg.Rounding)
+		if err != nil {
+			log.Warn("out", zap.String("result", "payment_not_settled"), zap.Error(err))
+			return dto.Quote{}, err
+		}
+
+		// pembulatan hanya untuk bagian cash, sama seperti checkout
+		res.CashTotal = pricing.Total + settled.Rounding
+		res.RoundingAmount = settled.Rounding
+		res.PaidAmount = settled.Paid
+		res.ChangeAmount = settled.Change
+		earnBase += settled.Rounding - paidWith(payments, entity.PaymentMethodPoints)
+	}
+
+	if req.CustomerID != nil {
+		res.PointsEarned = s.cfg.Loyalty.earn(earnBase)
+	}
+
+	// stock dicek per product, baris duplikat dijumlahkan
+	requested := map[uint]int{}
+	for _, line := range pricing.Lines {
+		requested[line.Product.ID] += line.Quantity
+	}
+
+	res.Lines = make([]dto.QuoteLine, 0, len(pricing.Lines))
+	for _, line := range pricing.Lines {
+		inStock := requested[line.Product.ID] <= line.Product.Stock
+		if !inStock {
+			res.InStock = false
+		}
+
+		res.Lines = append(res.Lines, dto.QuoteLine{
+			ProductID:           line.Product.ID,
+			ProductName:         line.Product.Name,
+			CategoryID:          line.Product.CategoryID,
+			CategoryName:        line.Product.CategoryName,
+			BasePrice:           line.BasePrice,
+			UnitPrice:           line.UnitPrice,
+			PriceListName:       line.PriceList.name(),
+			Quantity:            line.Quantity,
+			DiscountAmount:      line.LineDiscount,
+			CartDiscountAmount:  line.CartDiscount,
+			PromotionAmount:     line.Promotion,
+			PromotionName:       line.PromotionName,
+			VoucherAmount:       line.Voucher,
+			Subtotal:            line.Net(),
+			ServiceChargeAmount: line.ServiceCharge,
+			TaxName:             line.Tax.Name,
+			TaxRate:             line.Tax.Rate,
+			TaxInclusive:        line.Tax.Inclusive,
+			TaxBaseAmount:       line.TaxBase,
+			TaxAmount:           line.TaxAmount,
+			Total:               line.Total(),
+			AvailableStock:      line.Product.Stock,
+			InStock:             inStock,
 		})
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Bool("in_stock", res.InStock))
+
+	return res, nil
+}
+
+// maxItemQuantity batas quantity per baris checkout; angka di atas ini hampir pasti salah input.
+const maxItemQuantity = 100000
+
+func validateCheckoutItems(items []dto.CheckoutItem) error {
+	if len(items) <= 0 {
+		return InvalidInput("Items must be > 0")
+	}
+
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return InvalidInput("Quantity must be > 0")
+		}
+		if item.Quantity > maxItemQuantity {
+			return InvalidInput("Quantity must be <= 100000")
+		}
+	}
+
+	return nil
+}
+
+// mergeCheckoutItems gabungkan baris dengan product yang sama supaya stock dicek sekali
+// terhadap total quantity. Diskon fixed dijumlah, diskon percent hanya boleh digabung kalau
+// nilainya sama persis; kombinasi lain ditolak karena hasilnya jadi ambigu.
+func mergeCheckoutItems(items []dto.CheckoutItem) ([]dto.CheckoutItem, error) {
+	merged := make([]dto.CheckoutItem, 0, len(items))
+	index := make(map[uint]int, len(items))
+
+	for _, item := range items {
+		if item.Discount != nil {
+			// salinan supaya request asli tidak ikut berubah
+			d := *item.Discount
+			d.Type = normalizeDiscountType(d.Type)
+			item.Discount = &d
+		}
+
+		i, ok := index[item.ProductID]
+		if !ok {
+			index[item.ProductID] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+
+		discount, err := mergeLineDiscount(merged[i].Discount, item.Discount)
 		if err != nil {
-			log.Warn("out", zap.String("result", "repository_error"))
-			return dto.Transaction{}, err
+			return nil, err
+		}
+
+		merged[i].Quantity += item.Quantity
+		merged[i].Discount = discount
+	}
+
+	return merged, nil
+}
+
+func mergeLineDiscount(a, b *dto.Discount) (*dto.Discount, error) {
+	switch {
+	case a == nil && b == nil:
+		return nil, nil
+	case a != nil && b != nil && a.Type == DiscountFixed && b.Type == DiscountFixed:
+		return &dto.Discount{Type: DiscountFixed, Value: a.Value + b.Value}, nil
+	case a != nil && b != nil && a.Type == DiscountPercent && b.Type == DiscountPercent && a.Value == b.Value:
+		return a, nil
+	case a == nil && b.Type == DiscountFixed:
+		return b, nil
+	case b == nil && a.Type == DiscountFixed:
+		return a, nil
+	}
+
+	return nil, InvalidInput("Conflicting discounts for the same product")
+}
+
+// priceCheckout load product & tarif pajak lalu hitung harga keranjang. Dipakai Checkout dan Quote
+// supaya angka di layar kasir dan transaksi final selalu sama.
+func (s *trxService) priceCheckout(ctx context.Context, req dto.Checkout) (cartPricing, error) {
+	items, err := mergeCheckoutItems(req.Items)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	// Get products dalam satu query
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+
+	found, err := s.productRepo.FindDetailByIDs(ctx, ids)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	products := make(map[uint]dto.ProductDetailResponse, len(found))
+	for _, p := range found {
+		products[p.ID] = p
+	}
+
+	if len(products) != len(ids) {
+		return cartPricing{}, NotFound("Product not found")
+	}
+
+	taxRates, err := s.taxRateRepo.FindAll(ctx)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	list, err := s.customerPriceList(ctx, req.CustomerID)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	tiers, err := s.productRepo.FindPriceTiers(ctx, ids)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	tiersByProduct := make(map[uint][]entity.ProductPriceTier, len(ids))
+	for _, t := range tiers {
+		tiersByProduct[t.ProductID] = append(tiersByProduct[t.ProductID], t)
+	}
+
+	now := time.Now()
+	promos, err := s.promoRepo.FindActive(ctx, now)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	voucher, err := s.checkoutVoucher(ctx, req, now)
+	if err != nil {
+		return cartPricing{}, err
+	}
+
+	return priceCart(pricingInput{
+		Items:             items,
+		Products:          products,
+		CartDiscount:      req.Discount,
+		TaxRates:          taxRates,
+		ServiceChargeRate: s.cfg.ServiceChargeRate,
+		PriceList:         list,
+		Tiers:             tiersByProduct,
+		Promotions:        activePromotions(promos, s.cfg.Invoice.period(now)),
+		Voucher:           voucher,
+	})
+}
+
+// checkoutVoucher cari voucher dari kode dan cek masih bisa dipakai. Kuota dicek lagi secara atomic
+// saat redeem di checkout, pengecekan di sini supaya quote & kasir dapat pesan yang jelas lebih awal.
+func (s *trxService) checkoutVoucher(ctx context.Context, req dto.Checkout, now time.Time) (*entity.Voucher, error) {
+	code := normalizeVoucherCode(req.VoucherCode)
+	if code == "" {
+		return nil, nil
+	}
+
+	v, err := s.voucherRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, NotFound("Voucher not found")
+		}
+		return nil, err
+	}
+
+	switch {
+	case !v.Active:
+		return nil, BadRequest("Voucher is not active")
+	case v.ExpiresAt != nil && !now.Before(*v.ExpiresAt):
+		return nil, BadRequest("Voucher has expired")
+	case v.UsageLimit > 0 && v.UsedCount >= v.UsageLimit:
+		return nil, BadRequest("Voucher usage limit reached")
+	}
+
+	if v.PerCustomerLimit > 0 {
+		if req.CustomerID == nil {
+			return nil, BadRequest("Voucher requires a customer")
+		}
+
+		used, err := s.voucherRepo.CountRedemptions(ctx, v.ID, *req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= v.PerCustomerLimit {
+			return nil, BadRequest("Voucher usage limit reached for this customer")
+		}
+	}
+
+	return &v, nil
+}
+
+// customerPriceList price list grup customer, nil kalau tanpa customer atau customer tidak punya grup.
+func (s *trxService) customerPriceList(ctx context.Context, customerID *uint) (*priceList, error) {
+	if customerID == nil {
+		return nil, nil
+	}
+
+	c, err := s.customerRepo.FindByID(ctx, *customerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, NotFound("Customer not found")
+		}
+		return nil, err
+	}
+
+	if c.CustomerGroupID == nil {
+		return nil, nil
+	}
+
+	group, err := s.groupRepo.FindByID(ctx, *c.CustomerGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.groupRepo.FindPrices(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &priceList{ID: group.ID, Name: group.Name, Rules: rules}, nil
+}
+
+// replayIdempotent claim idempotency key untuk request ini. Kalau key sudah pernah dipakai
+// dengan payload yang sama, hasil checkout sebelumnya di-decode ke res dan return true.
+func (s *trxService) replayIdempotent(ctx context.Context, key string, req dto.Checkout, res *dto.Transaction) (bool, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TrxService.replayIdempotent"),
+	)
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(payload)
+	reqHash := hex.EncodeToString(sum[:])
+
+	claimed, err := s.idemRepo.Claim(ctx, entity.IdempotencyKey{Key: key, RequestHash: reqHash})
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return false, err
+	}
+
+	if claimed {
+		return false, nil
+	}
+
+	existing, err := s.idemRepo.FindByKey(ctx, key)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return false, err
+	}
+
+	if existing.RequestHash != reqHash {
+		log.Warn("out", zap.String("result", "idempotency_key_reused"))
+		return false, Conflict("Idempotency-Key already used with a different payload")
+	}
+
+	if existing.ResponseBody == "" {
+		log.Error("out", zap.String("result", "missing_stored_response"))
+		return false, Internal("Internal server error")
+	}
+
+	if err := json.Unmarshal([]byte(existing.ResponseBody), res); err != nil {
+		log.Error("out", zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *trxService) GetTransactionByID(ctx context.Context, id uint) (dto.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TrxService.GetTransactionByID"),
+	)
+
+	log.Info("in")
+
+	trx, err := s.trxRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.Transaction{}, NotFound("Transaction not found")
 		}
+		log.Error("out", zap.Error(err))
+		return dto.Transaction{}, err
+	}
 
-		details[i].ID = trxDetRes.ID
-		details[i].TransactionID = trxDetRes.TransactionID
+	res, err := s.loadTransactions(ctx, []entity.Transaction{trx})
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.Transaction{}, err
 	}
 
 	log.Info("out", zap.String("result", "ok"))
 
+	return res[0], nil
+}
+
+func (s *trxService) GetAllTransaction(ctx context.Context, f dto.TransactionFilter) (dto.TransactionList, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "TrxService.GetAllTransaction"),
+	)
+
+	log.Info("in")
+
+	if f.StartDate != "" && f.EndDate != "" && f.StartDate > f.EndDate {
+		log.Warn("out", zap.String("result", "invalid_date_range"))
+		return dto.TransactionList{}, InvalidInput("Invalid input date")
+	}
+
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		log.Warn("out", zap.String("result", "invalid_amount_range"))
+		return dto.TransactionList{}, InvalidInput("Min amount must be <= max amount")
+	}
+
+	switch f.Status {
+	case "", entity.TrxStatusCompleted, entity.TrxStatusVoided, entity.TrxStatusRefunded:
+	default:
+		log.Warn("out", zap.String("result", "invalid_status"))
+		return dto.TransactionList{}, InvalidInput("Invalid status")
+	}
+
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.Limit <= 0 {
+		f.Limit = 20
+	}
+	if f.Limit > 100 {
+		f.Limit = 100
+	}
+
+	trxs, total, err := s.trxRepo.FindAll(ctx, f)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.TransactionList{}, err
+	}
+
+	items, err := s.loadTransactions(ctx, trxs)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return dto.TransactionList{}, err
+	}
+
+	res := dto.TransactionList{
+		Items: items,
+		Pagination: dto.Pagination{
+			Page:      f.Page,
+			Limit:     f.Limit,
+			TotalData: total,
+			TotalPage: int(math.Ceil(float64(total) / float64(f.Limit))),
+		},
+	}
+
+	log.Info("out", zap.Int("count", len(items)))
+
+	return res, nil
+}
+
+func (s *trxService) VoidTransaction(ctx context.Context, id uint, req dto.ReverseTransaction) (dto.Transaction, error) {
+	return s.reverseTransaction(ctx, "TrxService.VoidTransaction", id, req, entity.TrxStatusVoided)
+}
+
+func (s *trxService) RefundTransaction(ctx context.Context, id uint, req dto.ReverseTransaction) (dto.Transaction, error) {
+	return s.reverseTransaction(ctx, "TrxService.RefundTransaction", id, req, entity.TrxStatusRefunded)
+}
+
+// reverseTransaction dipakai void & full refund: ubah status lalu kembalikan stock, semuanya atomic.
+func (s *trxService) reverseTransaction(ctx context.Context, operation string, id uint, req dto.ReverseTransaction, status string) (dto.Transaction, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", operation),
+		zap.Uint("transaction_id", id),
+	)
+
+	log.Info("in")
+
+	if strings.TrimSpace(req.Reason) == "" {
+		log.Warn("out", zap.String("result", "reason_is_required"))
+		return dto.Transaction{}, InvalidInput("Reason is required")
+	}
+
+	refundMethod, err := normalizeRefundMethod(req.RefundMethod)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_refund_method"))
+		return dto.Transaction{}, err
+	}
+
+	terminalID, err := normalizeTerminalID(req.TerminalID)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_terminal"))
+		return dto.Transaction{}, err
+	}
+
+	var res dto.Transaction
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		trx, err := s.trxRepo.UpdateStatus(ctx, id, entity.TrxStatusCompleted, status, strings.TrimSpace(req.Reason))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				log.Warn("out", zap.String("result", "not_found"))
+				return NotFound("Transaction not found")
+			}
+			if errors.Is(err, repository.ErrConflict) {
+				log.Warn("out", zap.String("result", "conflict"))
+				return Conflict("Transaction is not completed")
+			}
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		details, err := s.trxDetRepo.FindByTransactionIDs(ctx, []uint{trx.ID})
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		// Uang yang dikembalikan = yang dibayar dikurangi yang sudah direfund lewat retur parsial
+		refundAmount := trx.RoundingAmount
+		for _, d := range details {
+			refundAmount += proratedAmount(linePaidAmount(d), d.Quantity, d.ReturnedQuantity, d.Quantity)
+		}
+
+		// Bagian yang dibayar pakai poin dikembalikan sebagai poin dan bagian kasbon mengurangi
+		// piutang, jadi keduanya tidak dikembalikan sebagai uang. Bagian yang sudah ikut retur
+		// parsial tidak dihitung lagi, dan kasbon yang sudah dilunasi dikembalikan sebagai uang
+		paidTotal, returned := returnedPaid(details)
+		var creditReleased int
+		if trx.CustomerID != nil {
+			payments, err := s.paymentRepo.FindByTransactionIDs(ctx, []uint{trx.ID})
+			if err != nil {
+				log.Error("out", zap.Error(err))
+				return err
+			}
+
+			refundAmount -= unreturnedShare(paymentTotal(payments, entity.PaymentMethodPoints), paidTotal, returned)
+
+			if credit := unreturnedShare(paymentTotal(payments, entity.PaymentMethodCredit), paidTotal, returned); credit > 0 {
+				creditReleased, err = s.creditRepo.Release(ctx, *trx.CustomerID, credit)
+				if err != nil {
+					log.Error("out", zap.Error(err))
+					return err
+				}
+				refundAmount -= creditReleased
+			}
+			refundAmount = max(refundAmount, 0)
+		}
+
+		// Kuota voucher dikembalikan supaya kodenya bisa dipakai lagi
+		if err := s.voucherRepo.Release(ctx, trx.ID); err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		// Poin yang ditukar dikembalikan, poin yang didapat ditarik lagi (sisa setelah retur parsial)
+		points := unreturnedShare(trx.PointsRedeemed, paidTotal, returned) - unreturnedShare(trx.PointsEarned, paidTotal, returned)
+		if err := postLoyalty(ctx, s.loyaltyRepo, trx, entity.LoyaltyReversal, points, status+" "+trx.InvoiceNumber); err != nil {
+			log.Warn("out", zap.String("result", "reverse_points_failed"), zap.Error(err))
+			return err
+		}
+
+		if terminalID == "" {
+			terminalID = trx.TerminalID
+		}
+
+		shiftID, err := refundShift(ctx, s.shiftRepo, terminalID, refundMethod, refundAmount)
+		if err != nil {
+			log.Warn("out", zap.String("result", "refund_shift_failed"), zap.Error(err))
+			return err
+		}
+
+		trx, err = s.trxRepo.RecordRefund(ctx, trx.ID, shiftID, refundMethod, refundAmount, creditReleased)
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+
+		// Restore product stock (unit yang sudah diretur parsial sudah dikembalikan sebelumnya)
+		for _, d := range details {
+			remaining := d.Quantity - d.ReturnedQuantity
+			if remaining <= 0 {
+				continue
+			}
+
+			if err := s.productRepo.IncreaseStock(ctx, d.ProductID, remaining); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					log.Warn("out", zap.String("result", "product_not_found"), zap.Uint("product_id", d.ProductID))
+					return NotFound("Product not found")
+				}
+				log.Error("out", zap.Error(err))
+				return err
+			}
+		}
+
+		loaded, err := s.loadTransactions(ctx, []entity.Transaction{trx})
+		if err != nil {
+			log.Error("out", zap.Error(err))
+			return err
+		}
+		res = loaded[0]
+
+		return nil
+	})
+	if err != nil {
+		return dto.Transaction{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.String("status", status))
+
+	return res, nil
+}
+
+// loadTransactions lengkapi header dengan detail & payment, masing-masing 1 query untuk semua transaction.
+func (s *trxService) loadTransactions(ctx context.Context, trxs []entity.Transaction) ([]dto.Transaction, error) {
+	ids := make([]uint, 0, len(trxs))
+	for _, t := range trxs {
+		ids = append(ids, t.ID)
+	}
+
+	details, err := s.trxDetRepo.FindByTransactionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepo.FindByTransactionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	detailsByTrx := make(map[uint][]dto.TransactionDetail, len(trxs))
+	for _, d := range details {
+		detailsByTrx[d.TransactionID] = append(detailsByTrx[d.TransactionID], d)
+	}
+
+	paymentsByTrx := make(map[uint][]entity.TransactionPayment, len(trxs))
+	for _, p := range payments {
+		paymentsByTrx[p.TransactionID] = append(paymentsByTrx[p.TransactionID], p)
+	}
+
+	res := make([]dto.Transaction, 0, len(trxs))
+	for _, t := range trxs {
+		res = append(res, toTransactionDTO(t, detailsByTrx[t.ID], paymentsByTrx[t.ID]))
+	}
+
+	return res, nil
+}
+
+func toTransactionDetailDTO(d entity.TransactionDetail) dto.TransactionDetail {
+	return dto.TransactionDetail{
+		ID:                  d.ID,
+		TransactionID:       d.TransactionID,
+		ProductID:           d.ProductID,
+		ProductName:         d.ProductName,
+		CategoryID:          d.CategoryID,
+		CategoryName:        d.CategoryName,
+		BasePrice:           d.BasePrice,
+		UnitPrice:           d.UnitPrice,
+		PriceListID:         d.PriceListID,
+		PriceListName:       d.PriceListName,
+		Quantity:            d.Quantity,
+		ReturnedQuantity:    d.ReturnedQuantity,
+		DiscountAmount:      d.DiscountAmount,
+		CartDiscountAmount:  d.CartDiscountAmount,
+		PromotionAmount:     d.PromotionAmount,
+		PromotionName:       d.PromotionName,
+		VoucherAmount:       d.VoucherAmount,
+		Subtotal:            d.Subtotal,
+		ServiceChargeAmount: d.ServiceChargeAmount,
+		TaxName:             d.TaxName,
+		TaxRate:             d.TaxRate,
+		TaxInclusive:        d.TaxInclusive,
+		TaxBaseAmount:       d.TaxBaseAmount,
+		TaxAmount:           d.TaxAmount,
+	}
+}
+
+func toTransactionDTO(trx entity.Transaction, details []dto.TransactionDetail, payments []entity.TransactionPayment) dto.Transaction {
+	if details == nil {
+		details = []dto.TransactionDetail{}
+	}
+
+	paymentRes := make([]dto.TransactionPayment, 0, len(payments))
+	for _, p := range payments {
+		paymentRes = append(paymentRes, dto.TransactionPayment{
+			ID:        p.ID,
+			Method:    p.Method,
+			Amount:    p.Amount,
+			Reference: p.Reference,
+		})
+	}
+
 	return dto.Transaction{
-		ID:        trxRes.ID,
-		Total:     trxRes.TotalAmount,
-		CreatedAt: trxRes.CreatedAt,
-		Details:   details,
-	}, nil
+		ID:                  trx.ID,
+		InvoiceNumber:       trx.InvoiceNumber,
+		TerminalID:          trx.TerminalID,
+		ShiftID:             trx.ShiftID,
+		CustomerID:          trx.CustomerID,
+		PointsEarned:        trx.PointsEarned,
+		PointsRedeemed:      trx.PointsRedeemed,
+		Subtotal:            trx.SubtotalAmount,
+		DiscountAmount:      trx.DiscountAmount,
+		CartDiscountAmount:  trx.CartDiscountAmount,
+		PromotionAmount:     trx.PromotionAmount,
+		VoucherCode:         trx.VoucherCode,
+		VoucherAmount:       trx.VoucherAmount,
+		ServiceChargeAmount: trx.ServiceChargeAmount,
+		TaxBaseAmount:       trx.TaxBaseAmount,
+		TaxAmount:           trx.TaxAmount,
+		Total:               trx.TotalAmount,
+		RoundingAmount:      trx.RoundingAmount,
+		PaidAmount:          trx.PaidAmount,
+		ChangeAmount:        trx.ChangeAmount,
+		Status:              trx.Status,
+		StatusReason:        trx.StatusReason,
+		StatusUpdatedAt:     trx.StatusUpdatedAt,
+		RefundMethod:        trx.RefundMethod,
+		RefundAmount:        trx.RefundAmount,
+		RefundShiftID:       trx.RefundShiftID,
+		CreatedAt:           trx.CreatedAt,
+		Details:             details,
+		Payments:            paymentRes,
+	}
 }
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kasir-api/internal/delivery/http/middleware"
+	"kasir-api/internal/dto"
+	"kasir-api/internal/entity"
+	"kasir-api/internal/repository"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type VoucherService interface {
+	CreateVoucher(ctx context.Context, req dto.Voucher) (dto.VoucherResponse, error)
+	GetVoucherByID(ctx context.Context, id uint) (dto.VoucherResponse, error)
+	GetAllVoucher(ctx context.Context) ([]dto.VoucherResponse, error)
+	UpdateVoucherByID(ctx context.Context, id uint, req dto.Voucher) (dto.VoucherResponse, error)
+	DeleteVoucherByID(ctx context.Context, id uint) error
+}
+
+type voucherService struct {
+	txManager    repository.TxManager
+	voucherRepo  repository.VoucherRepository
+	categoryRepo repository.CategoryRepository
+}
+
+func NewVoucherService(txManager repository.TxManager, voucherRepo repository.VoucherRepository, categoryRepo repository.CategoryRepository) VoucherService {
+	return &voucherService{txManager: txManager, voucherRepo: voucherRepo, categoryRepo: categoryRepo}
+}
+
+func (s *voucherService) CreateVoucher(ctx context.Context, req dto.Voucher) (dto.VoucherResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "VoucherService.CreateVoucher"),
+	)
+
+	log.Info("in")
+
+	v, err := s.validateVoucher(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.VoucherResponse{}, err
+	}
+
+	var created entity.Voucher
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.voucherRepo.Create(ctx, v)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.VoucherResponse{}, Conflict("Voucher code already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.VoucherResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"), zap.Uint("voucher_id", created.ID))
+
+	return toVoucherDTO(created), nil
+}
+
+func (s *voucherService) GetVoucherByID(ctx context.Context, id uint) (dto.VoucherResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "VoucherService.GetVoucherByID"),
+	)
+
+	log.Info("in")
+
+	v, err := s.voucherRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.VoucherResponse{}, NotFound("Voucher not found")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.VoucherResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toVoucherDTO(v), nil
+}
+
+func (s *voucherService) GetAllVoucher(ctx context.Context) ([]dto.VoucherResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "VoucherService.GetAllVoucher"),
+	)
+
+	log.Info("in")
+
+	vouchers, err := s.voucherRepo.FindAll(ctx)
+	if err != nil {
+		log.Error("out", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]dto.VoucherResponse, 0, len(vouchers))
+	for _, v := range vouchers {
+		res = append(res, toVoucherDTO(v))
+	}
+
+	log.Info("out", zap.Int("count", len(res)))
+
+	return res, nil
+}
+
+func (s *voucherService) UpdateVoucherByID(ctx context.Context, id uint, req dto.Voucher) (dto.VoucherResponse, error) {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "VoucherService.UpdateVoucherByID"),
+	)
+
+	log.Info("in")
+
+	v, err := s.validateVoucher(ctx, req)
+	if err != nil {
+		log.Warn("out", zap.String("result", "invalid_input"), zap.Error(err))
+		return dto.VoucherResponse{}, err
+	}
+	v.ID = id
+
+	var updated entity.Voucher
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.voucherRepo.Update(ctx, v)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return dto.VoucherResponse{}, NotFound("Voucher not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Warn("out", zap.String("result", "conflict"))
+			return dto.VoucherResponse{}, Conflict("Voucher code already exists")
+		}
+		log.Error("out", zap.Error(err))
+		return dto.VoucherResponse{}, err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return toVoucherDTO(updated), nil
+}
+
+func (s *voucherService) DeleteVoucherByID(ctx context.Context, id uint) error {
+	log := middleware.LoggerFromCtx(ctx).With(
+		zap.String("layer", "service"),
+		zap.String("operation", "VoucherService.DeleteVoucherByID"),
+	)
+
+	log.Info("in")
+
+	if err := s.voucherRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Warn("out", zap.String("result", "not_found"))
+			return NotFound("Voucher not found")
+		}
+		if errors.Is(err, repository.ErrForbidden) {
+			log.Warn("out", zap.String("result", "forbidden"))
+			return Forbidden("Voucher already used, deactivate it instead")
+		}
+		log.Error("out", zap.Error(err))
+		return err
+	}
+
+	log.Info("out", zap.String("result", "ok"))
+
+	return nil
+}
+
+func (s *voucherService) validateVoucher(ctx context.Context, req dto.Voucher) (entity.Voucher, error) {
+	code := normalizeVoucherCode(req.Code)
+	if code == "" {
+		return entity.Voucher{}, InvalidInput("Code is required")
+	}
+	if len(code) > 32 || strings.ContainsAny(code, " \t\n") {
+		return entity.Voucher{}, InvalidInput("Code must be at most 32 characters without spaces")
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(req.Type))
+	switch kind {
+	case entity.VoucherFixed:
+		if req.Value <= 0 {
+			return entity.Voucher{}, InvalidInput("Value must be greater than 0")
+		}
+	case entity.VoucherPercent:
+		if req.Value <= 0 || req.Value > 100 {
+			return entity.Voucher{}, InvalidInput("Percent must be between 0 and 100")
+		}
+	default:
+		return entity.Voucher{}, InvalidInput("Invalid voucher type")
+	}
+
+	if req.MaxDiscount < 0 || req.MinSpend < 0 {
+		return entity.Voucher{}, InvalidInput("Max discount and min spend must be >= 0")
+	}
+	if req.UsageLimit < 0 || req.PerCustomerLimit < 0 {
+		return entity.Voucher{}, InvalidInput("Usage limits must be >= 0")
+	}
+
+	// category duplikat dibuang supaya insert voucher_category tidak bentrok primary key
+	seen := make(map[uint]bool, len(req.CategoryIDs))
+	categoryIDs := make([]uint, 0, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, err := s.categoryRepo.FindByID(ctx, id); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return entity.Voucher{}, NotFound("Category not found")
+			}
+			return entity.Voucher{}, err
+		}
+		categoryIDs = append(categoryIDs, id)
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i] < categoryIDs[j] })
+
+	return entity.Voucher{
+		Code:             code,
+		Description:      strings.TrimSpace(req.Description),
+		Type:             kind,
+		Value:            req.Value,
+		MaxDiscount:      req.MaxDiscount,
+		MinSpend:         req.MinSpend,
+		ExpiresAt:        req.ExpiresAt,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		Active:           req.Active == nil || *req.Active,
+		CategoryIDs:      categoryIDs,
+	}, nil
+}
+
+// normalizeVoucherCode kode voucher tidak case-sensitive, disimpan & dicari dalam uppercase.
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func voucherID(v *entity.Voucher) *uint {
+	if v == nil {
+		return nil
+	}
+	return &v.ID
+}
+
+func voucherCode(v *entity.Voucher) string {
+	if v == nil {
+		return ""
+	}
+	return v.Code
+}
+
+func toVoucherDTO(v entity.Voucher) dto.VoucherResponse {
+	categoryIDs := v.CategoryIDs
+	if categoryIDs == nil {
+		categoryIDs = []uint{}
+	}
+
+	return dto.VoucherResponse{
+		ID:               v.ID,
+		Code:             v.Code,
+		Description:      v.Description,
+		Type:             v.Type,
+		Value:            v.Value,
+		MaxDiscount:      v.MaxDiscount,
+		MinSpend:         v.MinSpend,
+		ExpiresAt:        v.ExpiresAt,
+		UsageLimit:       v.UsageLimit,
+		PerCustomerLimit: v.PerCustomerLimit,
+		UsedCount:        v.UsedCount,
+		CategoryIDs:      categoryIDs,
+		Active:           v.Active,
+		CreatedAt:        v.CreatedAt,
+		UpdatedAt:        v.UpdatedAt,
+	}
+}
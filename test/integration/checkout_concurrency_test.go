@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func checkoutBody(terminal string, productID uint, qty, amount int) map[string]any {
+	return map[string]any{
+		"terminal_id": terminal,
+		"items":       []map[string]any{{"product_id": productID, "quantity": qty}},
+		"payments":    []map[string]any{{"method": "cash", "amount": amount}},
+	}
+}
+
+// TestCheckoutConcurrentLastUnits banyak kasir rebutan stock terakhir:
+// yang sukses tepat sebanyak stock, sisanya "Stock not enough", stock akhir 0.
+func TestCheckoutConcurrentLastUnits(t *testing.T) {
+	requireDB(t)
+
+	const stock, buyers = 10, 40
+	product := createProduct(t, 1000, stock)
+	terminal := openShift(t)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		success  int
+		rejected int
+	)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res := doJSON(t, "POST", "/api/transaction/checkout", checkoutBody(terminal, product.ID, 1, 100000), nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case res.Code == 201:
+				success++
+			case res.Code == 400 && res.Message == "Stock not enough":
+				rejected++
+			default:
+				t.Errorf("unexpected checkout response: %d %s", res.Code, res.Message)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if success != stock {
+		t.Errorf("success = %d, want %d", success, stock)
+	}
+	if rejected != buyers-stock {
+		t.Errorf("rejected = %d, want %d", rejected, buyers-stock)
+	}
+	if got := getProduct(t, product.ID).Stock; got != 0 {
+		t.Errorf("final stock = %d, want 0", got)
+	}
+}
+
+// TestCheckoutConcurrentNoDrift qty acak paralel: stock akhir harus tepat
+// stock awal dikurangi qty checkout yang sukses, tidak pernah negatif.
+func TestCheckoutConcurrentNoDrift(t *testing.T) {
+	requireDB(t)
+
+	const stock, buyers = 50, 60
+	product := createProduct(t, 1000, stock)
+	terminal := openShift(t)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sold int
+	)
+	for i := 0; i < buyers; i++ {
+		qty := 1 + rand.Intn(3)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res := doJSON(t, "POST", "/api/transaction/checkout", checkoutBody(terminal, product.ID, qty, 100000), nil)
+			if res.Code == 201 {
+				mu.Lock()
+				sold += qty
+				mu.Unlock()
+				return
+			}
+			if res.Code != 400 || res.Message != "Stock not enough" {
+				t.Errorf("unexpected checkout response: %d %s", res.Code, res.Message)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := getProduct(t, product.ID).Stock
+	if got < 0 {
+		t.Fatalf("stock went negative: %d", got)
+	}
+	if got != stock-sold {
+		t.Errorf("final stock = %d, want %d (sold %d)", got, stock-sold, sold)
+	}
+}
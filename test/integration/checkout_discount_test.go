@@ -0,0 +1,53 @@
+package integration
+
+import "testing"
+
+func quoteBody(productID uint, discounts ...map[string]any) map[string]any {
+	items := make([]map[string]any, 0, len(discounts))
+	for _, d := range discounts {
+		items = append(items, map[string]any{"product_id": productID, "quantity": 1, "discount": d})
+	}
+
+	return map[string]any{"items": items}
+}
+
+// TestQuoteMergeDiscountTypeCase baris product yang sama digabung; type diskon tidak case-sensitive
+// seperti diskon satu baris.
+func TestQuoteMergeDiscountTypeCase(t *testing.T) {
+	requireDB(t)
+
+	product := createProduct(t, 10000, 100)
+
+	var quote struct {
+		DiscountAmount int `json:"discount_amount"`
+	}
+	res := doJSON(t, "POST", "/api/transaction/quote", quoteBody(product.ID,
+		map[string]any{"type": "Fixed", "value": 100},
+		map[string]any{"type": " fixed", "value": 200},
+	), &quote)
+	if res.Code != 200 {
+		t.Fatalf("fixed discounts: %d %s", res.Code, res.Message)
+	}
+	if quote.DiscountAmount != 300 {
+		t.Errorf("fixed discount = %d, want 300", quote.DiscountAmount)
+	}
+
+	res = doJSON(t, "POST", "/api/transaction/quote", quoteBody(product.ID,
+		map[string]any{"type": "PERCENT", "value": 10},
+		map[string]any{"type": "percent", "value": 10},
+	), &quote)
+	if res.Code != 200 {
+		t.Fatalf("percent discounts: %d %s", res.Code, res.Message)
+	}
+	if quote.DiscountAmount != 2000 {
+		t.Errorf("percent discount = %d, want 2000", quote.DiscountAmount)
+	}
+
+	res = doJSON(t, "POST", "/api/transaction/quote", quoteBody(product.ID,
+		map[string]any{"type": "Percent", "value": 10},
+		map[string]any{"type": "percent", "value": 20},
+	), nil)
+	if res.Code != 400 {
+		t.Errorf("different percent discounts = %d %s, want 400", res.Code, res.Message)
+	}
+}
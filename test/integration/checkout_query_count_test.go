@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+var (
+	queryCount     atomic.Int64
+	queryCountOnce sync.Once
+)
+
+// countQueries pasang callback gorm sekali, tiap statement ke database menambah queryCount.
+func countQueries(tb testing.TB) {
+	tb.Helper()
+
+	queryCountOnce.Do(func() {
+		inc := func(*gorm.DB) { queryCount.Add(1) }
+		cb := testDB.Callback()
+		for _, err := range []error{
+			cb.Create().After("gorm:create").Register("test:count_create", inc),
+			cb.Query().After("gorm:query").Register("test:count_query", inc),
+			cb.Update().After("gorm:update").Register("test:count_update", inc),
+			cb.Delete().After("gorm:delete").Register("test:count_delete", inc),
+			cb.Row().After("gorm:row").Register("test:count_row", inc),
+			cb.Raw().After("gorm:raw").Register("test:count_raw", inc),
+		} {
+			if err != nil {
+				tb.Fatal(err)
+			}
+		}
+	})
+}
+
+// cartCheckout satu checkout dengan items product berbeda, bayar cash pas-pasan dibulatkan ke atas.
+func cartCheckout(terminal string, products []productFixture) map[string]any {
+	items := make([]map[string]any, 0, len(products))
+	total := 0
+	for _, p := range products {
+		items = append(items, map[string]any{"product_id": p.ID, "quantity": 1})
+		total += p.Price
+	}
+
+	return map[string]any{
+		"terminal_id": terminal,
+		"items":       items,
+		"payments":    []map[string]any{{"method": "cash", "amount": total * 2}},
+	}
+}
+
+// checkoutQueries jumlah query database untuk satu checkout.
+func checkoutQueries(tb testing.TB, body map[string]any) int64 {
+	tb.Helper()
+
+	before := queryCount.Load()
+	res := doJSON(tb, "POST", "/api/transaction/checkout", body, nil)
+	if res.Code != 201 {
+		tb.Fatalf("checkout: %d %s", res.Code, res.Message)
+	}
+
+	return queryCount.Load() - before
+}
+
+func createProducts(tb testing.TB, n, stock int) []productFixture {
+	tb.Helper()
+
+	products := make([]productFixture, 0, n)
+	for i := 0; i < n; i++ {
+		products = append(products, createProduct(tb, 1000+i, stock))
+	}
+	return products
+}
+
+// TestCheckoutQueryCountConstant jumlah query checkout tidak boleh bertambah mengikuti jumlah item.
+func TestCheckoutQueryCountConstant(t *testing.T) {
+	requireDB(t)
+	countQueries(t)
+
+	terminal := openShift(t)
+	products := createProducts(t, 40, 100)
+
+	want := checkoutQueries(t, cartCheckout(terminal, products[:1]))
+	for _, n := range []int{5, 20, 40} {
+		if got := checkoutQueries(t, cartCheckout(terminal, products[:n])); got != want {
+			t.Errorf("checkout with %d items: %d queries, want %d (same as 1 item)", n, got, want)
+		}
+	}
+}
+
+func BenchmarkCheckout(b *testing.B) {
+	requireDB(b)
+	countQueries(b)
+
+	terminal := openShift(b)
+	products := createProducts(b, 40, 1_000_000)
+
+	for _, n := range []int{1, 10, 40} {
+		b.Run(fmt.Sprintf("items=%d", n), func(b *testing.B) {
+			body := cartCheckout(terminal, products[:n])
+
+			var queries int64
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				queries += checkoutQueries(b, body)
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type customerFixture struct {
+	ID    uint   `json:"id"`
+	Phone string `json:"phone"`
+}
+
+func createCustomer(tb testing.TB, phone string) customerFixture {
+	tb.Helper()
+
+	var c customerFixture
+	res := doJSON(tb, "POST", "/api/customer", map[string]any{
+		"name":  uniqueName("customer"),
+		"phone": phone,
+	}, &c)
+	if res.Code != 201 {
+		tb.Fatalf("create customer: %d %s", res.Code, res.Message)
+	}
+
+	return c
+}
+
+func searchCustomers(tb testing.TB, q string) []customerFixture {
+	tb.Helper()
+
+	var list struct {
+		Items []customerFixture `json:"items"`
+	}
+	res := doJSON(tb, "GET", "/api/customer?limit=100&q="+url.QueryEscape(q), nil, &list)
+	if res.Code != 200 {
+		tb.Fatalf("search customer %q: %d %s", q, res.Code, res.Message)
+	}
+
+	return list.Items
+}
+
+// TestCustomerSearchFormattedPhone nomor HP tersimpan ternormalisasi, pencarian dengan
+// nomor berformat (spasi, strip, kurung) tetap harus ketemu.
+func TestCustomerSearchFormattedPhone(t *testing.T) {
+	requireDB(t)
+
+	digits := fmt.Sprintf("%09d", time.Now().UnixNano()%1_000_000_000)
+	customer := createCustomer(t, "0812-"+digits[:4]+" "+digits[4:])
+	if want := "0812" + digits; customer.Phone != want {
+		t.Fatalf("stored phone = %q, want %q", customer.Phone, want)
+	}
+
+	for _, q := range []string{
+		"0812" + digits,
+		"0812-" + digits[:4] + " " + digits[4:],
+		"(0812) " + digits[:4],
+		digits[:3] + "." + digits[3:6],
+	} {
+		found := false
+		for _, c := range searchCustomers(t, q) {
+			if c.ID == customer.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("search %q: customer %d not found", q, customer.ID)
+		}
+	}
+}
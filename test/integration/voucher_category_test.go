@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestDeleteCategoryUsedByVoucher category yang membatasi voucher tidak boleh terhapus,
+// kalau terhapus voucher jadi berlaku untuk semua category.
+func TestDeleteCategoryUsedByVoucher(t *testing.T) {
+	requireDB(t)
+
+	var category struct {
+		ID uint `json:"id"`
+	}
+	res := doJSON(t, "POST", "/api/category", map[string]any{"name": uniqueName("category")}, &category)
+	if res.Code != 201 {
+		t.Fatalf("create category: %d %s", res.Code, res.Message)
+	}
+
+	var voucher struct {
+		ID          uint   `json:"id"`
+		CategoryIDs []uint `json:"category_ids"`
+	}
+	res = doJSON(t, "POST", "/api/voucher", map[string]any{
+		"code":         strings.ToUpper(uniqueName("V")),
+		"type":         "fixed",
+		"value":        1000,
+		"category_ids": []uint{category.ID},
+	}, &voucher)
+	if res.Code != 201 {
+		t.Fatalf("create voucher: %d %s", res.Code, res.Message)
+	}
+
+	res = doJSON(t, "DELETE", fmt.Sprintf("/api/category/%d", category.ID), nil, nil)
+	if res.Code != 409 {
+		t.Fatalf("delete category = %d %s, want 409", res.Code, res.Message)
+	}
+
+	res = doJSON(t, "GET", fmt.Sprintf("/api/voucher/%d", voucher.ID), nil, &voucher)
+	if res.Code != 200 {
+		t.Fatalf("get voucher: %d %s", res.Code, res.Message)
+	}
+	if len(voucher.CategoryIDs) != 1 || voucher.CategoryIDs[0] != category.ID {
+		t.Errorf("voucher categories = %v, want [%d]", voucher.CategoryIDs, category.ID)
+	}
+}